@@ -19,6 +19,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync/atomic"
 )
 
 // Call represents an expected call to a mock.
@@ -36,12 +37,25 @@ type Call struct {
 	// Expectations
 	minCalls, maxCalls int
 
-	numCalls int // actual number made
+	numCalls int64 // actual number made, accessed atomically
+
+	// superseded is set when a call declared to follow this one is made,
+	// after which this one is no longer expected.
+	superseded atomic.Bool
 
 	// actions are called when this Call is called. Each action gets the args and
 	// can set the return values by returning a non-nil slice. Actions run in the
 	// order they are created.
 	actions []func([]any) []any
+
+	// ctrl is the controller of the mock, whose default return values are
+	// returned when no action sets them, instead of zero values. It may be
+	// nil.
+	ctrl *Controller
+
+	// callSet is the set of expected calls the call was added to. It may be
+	// nil.
+	callSet *callSet
 }
 
 // newCall creates a *Call. It requires the method type in order to support
@@ -67,18 +81,23 @@ func newCall(t TestHelper, receiver any, method string, methodType reflect.Type,
 	// and this line changes, i.e. this code is wrapped in another anonymous function.
 	// 0 is us, 1 is RecordCallWithMethodType(), 2 is the generated recorder, and 3 is the user's test.
 	origin := callerInfo(3)
-	actions := []func([]any) []any{func([]any) []any {
-		// Synthesize the zero value for each of the return args' types.
+	c := &Call{
+		t: t, receiver: receiver, method: method, methodType: methodType,
+		args: mArgs, origin: origin, minCalls: 1, maxCalls: 1,
+	}
+	c.actions = []func([]any) []any{func([]any) []any {
+		// Synthesize the default value for each of the return args' types.
 		rets := make([]any, methodType.NumOut())
 		for i := 0; i < methodType.NumOut(); i++ {
-			rets[i] = reflect.Zero(methodType.Out(i)).Interface()
+			if c.ctrl != nil {
+				rets[i] = c.ctrl.innermost().defaultReturn(receiver, methodType.Out(i))
+			} else {
+				rets[i] = reflect.Zero(methodType.Out(i)).Interface()
+			}
 		}
 		return rets
 	}}
-	return &Call{
-		t: t, receiver: receiver, method: method, methodType: methodType,
-		args: mArgs, origin: origin, minCalls: 1, maxCalls: 1, actions: actions,
-	}
+	return c
 }
 
 // AnyTimes allows the expectation to be called 0 or more times
@@ -107,6 +126,22 @@ func (c *Call) MaxTimes(n int) *Call {
 	return c
 }
 
+// Override replaces the expected calls of the same method of the same mock
+// recorded before c, which are no longer matched:
+//
+//	mock.EXPECT().Get("key").Return("value", nil).AnyTimes()
+//	// ...
+//	mock.EXPECT().Get("key").Return("", ErrNotFound).Override()
+//
+// The calls overridden within a scope pushed with [Controller.PushScope] are
+// restored when it is popped. See also [WithOverridableExpectations].
+func (c *Call) Override() *Call {
+	if c.callSet != nil {
+		c.callSet.Override(c)
+	}
+	return c
+}
+
 // DoAndReturn declares the action to run when the call is matched.
 // The return values from this function are returned by the mocked function.
 // It takes an any argument to support n-arity functions.
@@ -224,6 +259,38 @@ func (c *Call) Return(rets ...any) *Call {
 	return c
 }
 
+// Panic declares that the mocked function call panics with v, e.g. to
+// simulate failures.
+func (c *Call) Panic(v any) *Call {
+	c.addAction(func([]any) []any {
+		panic(v)
+	})
+	return c
+}
+
+// ReturnError declares that the mocked function call returns err as its last
+// result, which must be of type error, and zero values as its other results.
+func (c *Call) ReturnError(err error) *Call {
+	c.t.Helper()
+
+	mt := c.methodType
+	if mt.NumOut() == 0 || mt.Out(mt.NumOut()-1) != reflect.TypeOf((*error)(nil)).Elem() {
+		c.t.Fatalf("ReturnError for %T.%v: the last result is not an error [%s]",
+			c.receiver, c.method, c.origin)
+		return c
+	}
+	rets := make([]any, mt.NumOut())
+	for i := 0; i < len(rets)-1; i++ {
+		rets[i] = reflect.Zero(mt.Out(i)).Interface()
+	}
+	rets[len(rets)-1] = err
+
+	c.addAction(func([]any) []any {
+		return rets
+	})
+	return c
+}
+
 // Times declares the exact number of times a function call is expected to be executed.
 func (c *Call) Times(n int) *Call {
 	c.minCalls, c.maxCalls = n, n
@@ -302,12 +369,12 @@ func (c *Call) After(preReq *Call) *Call {
 
 // Returns true if the minimum number of calls have been made.
 func (c *Call) satisfied() bool {
-	return c.numCalls >= c.minCalls
+	return atomic.LoadInt64(&c.numCalls) >= int64(c.minCalls)
 }
 
 // Returns true if the maximum number of calls have been made.
 func (c *Call) exhausted() bool {
-	return c.numCalls >= c.maxCalls
+	return atomic.LoadInt64(&c.numCalls) >= int64(c.maxCalls)
 }
 
 func (c *Call) String() string {
@@ -322,6 +389,16 @@ func (c *Call) String() string {
 // Tests if the given call matches the expected call.
 // If yes, returns nil. If no, returns error with message explaining why it does not match.
 func (c *Call) matches(args []any) error {
+	if err := c.matchesArgs(args); err != nil {
+		return err
+	}
+	return c.matchesState()
+}
+
+// matchesArgs tests if the arguments of the given call match those of the
+// expected call. It runs the matchers, which may call mocks, so it must be
+// called without holding any lock.
+func (c *Call) matchesArgs(args []any) error {
 	if !c.methodType.IsVariadic() {
 		if len(args) != len(c.args) {
 			return fmt.Errorf("expected call at %s has the wrong number of arguments. Got: %d, want: %d",
@@ -402,7 +479,12 @@ func (c *Call) matches(args []any) error {
 				c.origin, strconv.Itoa(i), formatGottenArg(m, args[i:]), c.args[i])
 		}
 	}
+	return nil
+}
 
+// matchesState tests if the prerequisite calls of the expected call have been
+// made and if it can still be called.
+func (c *Call) matchesState() error {
 	// Check that all prerequisite calls have been satisfied.
 	for _, preReqCall := range c.preReqs {
 		if !preReqCall.satisfied() {
@@ -428,7 +510,7 @@ func (c *Call) dropPrereqs() (preReqs []*Call) {
 }
 
 func (c *Call) call() []func([]any) []any {
-	c.numCalls++
+	atomic.AddInt64(&c.numCalls, 1)
 	return c.actions
 }
 
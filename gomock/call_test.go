@@ -621,6 +621,73 @@ func TestCall_DoAndReturn(t *testing.T) {
 	}
 }
 
+func TestCall_Panic(t *testing.T) {
+	c := newCall(t, nil, "Foo", reflect.TypeOf(func() error { return nil })).Panic("boom")
+
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("got panic %v, want boom", r)
+		}
+	}()
+	for _, action := range c.actions {
+		action(nil)
+	}
+	t.Error("expected Panic to panic")
+}
+
+func TestCall_ReturnError(t *testing.T) {
+	errFoo := fmt.Errorf("foo")
+	tests := []struct {
+		name       string
+		methodType reflect.Type
+		wantRets   []any
+		wantFatal  bool
+	}{
+		{
+			name:       "only error",
+			methodType: reflect.TypeOf(func() error { return nil }),
+			wantRets:   []any{errFoo},
+		},
+		{
+			name:       "zero values",
+			methodType: reflect.TypeOf(func() (*a, b, int, error) { return nil, b{}, 0, nil }),
+			wantRets:   []any{(*a)(nil), b{}, 0, errFoo},
+		},
+		{
+			name:       "no error",
+			methodType: reflect.TypeOf(func() (int, string) { return 0, "" }),
+			wantFatal:  true,
+		},
+		{
+			name:       "no results",
+			methodType: reflect.TypeOf(func() {}),
+			wantFatal:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &mockTestReporter{}
+			c := newCall(tr, nil, "Foo", tt.methodType).ReturnError(errFoo)
+			if tt.wantFatal {
+				if tr.fatalCalls != 1 {
+					t.Errorf("got %d fatal calls, want 1", tr.fatalCalls)
+				}
+				return
+			}
+
+			var rets []any
+			for _, action := range c.actions {
+				if r := action(nil); r != nil {
+					rets = r
+				}
+			}
+			if !reflect.DeepEqual(rets, tt.wantRets) {
+				t.Errorf("got %v, want %v", rets, tt.wantRets)
+			}
+		})
+	}
+}
+
 func TestInOrder(t *testing.T) {
 	t.Run("process only *Call or its wrappers", func(t *testing.T) {
 		tr1 := &mockTestReporter{}
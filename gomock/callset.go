@@ -18,19 +18,23 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 )
 
 // callSet represents a set of expected calls, indexed by receiver and method
-// name.
+// name. The calls of each receiver and method are guarded by their own lock,
+// so that calls of different methods are matched concurrently.
 type callSet struct {
-	// Calls that are still expected.
-	expected   map[callSetKey][]*Call
-	expectedMu *sync.Mutex
-	// Calls that have been exhausted.
-	exhausted map[callSetKey][]*Call
+	// mu guards calls and scopes. It is held for writing to add calls and to
+	// push and pop scopes, and for reading to look up the calls of a receiver
+	// and method.
+	mu    sync.RWMutex
+	calls map[callSetKey]*callList
 	// when set to true, existing call expectations are overridden when new call expectations are made
 	allowOverride bool
+	// The scopes pushed on top of the initial expectations, innermost last.
+	scopes []*callScope
 }
 
 // callSetKey is the key in the maps in callSet
@@ -39,83 +43,275 @@ type callSetKey struct {
 	fname    string
 }
 
+// callList holds the calls of a receiver and method. The slices of calls are
+// never modified in place, only replaced or appended to, so that findMatch
+// can iterate over them without holding mu and without copying them.
+type callList struct {
+	mu sync.Mutex
+	// Calls that are still expected.
+	expected []*Call
+	// Calls that have been exhausted.
+	exhausted []*Call
+}
+
+// callScope records the changes made to a callSet since a scope was pushed,
+// so that they can be undone when it is popped.
+type callScope struct {
+	// Calls added in the scope.
+	added []*Call
+	// Calls expected before the scope and overridden in it.
+	overridden map[callSetKey][]*Call
+}
+
 func newCallSet() *callSet {
 	return &callSet{
-		expected:   make(map[callSetKey][]*Call),
-		expectedMu: &sync.Mutex{},
-		exhausted:  make(map[callSetKey][]*Call),
+		calls: make(map[callSetKey]*callList),
 	}
 }
 
 func newOverridableCallSet() *callSet {
 	return &callSet{
-		expected:      make(map[callSetKey][]*Call),
-		expectedMu:    &sync.Mutex{},
-		exhausted:     make(map[callSetKey][]*Call),
+		calls:         make(map[callSetKey]*callList),
 		allowOverride: true,
 	}
 }
 
+// list returns the calls of key, or nil if there are none.
+func (cs *callSet) list(key callSetKey) *callList {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.calls[key]
+}
+
 // Add adds a new expected call.
-func (cs callSet) Add(call *Call) {
+func (cs *callSet) Add(call *Call) {
 	key := callSetKey{call.receiver, call.method}
 
-	cs.expectedMu.Lock()
-	defer cs.expectedMu.Unlock()
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	l := cs.calls[key]
+	if l == nil {
+		l = &callList{}
+		cs.calls[key] = l
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
 
-	m := cs.expected
 	if call.exhausted() {
-		m = cs.exhausted
+		l.exhausted = append(l.exhausted, call)
+	} else {
+		if cs.allowOverride {
+			cs.override(key, l)
+		}
+		l.expected = append(l.expected, call)
 	}
-	if cs.allowOverride {
-		m[key] = make([]*Call, 0)
+	if len(cs.scopes) > 0 {
+		scope := cs.scopes[len(cs.scopes)-1]
+		scope.added = append(scope.added, call)
 	}
-
-	m[key] = append(m[key], call)
 }
 
-// Remove removes an expected call.
-func (cs callSet) Remove(call *Call) {
+// Override removes the calls expected before call for the same receiver and
+// method.
+func (cs *callSet) Override(call *Call) {
 	key := callSetKey{call.receiver, call.method}
 
-	cs.expectedMu.Lock()
-	defer cs.expectedMu.Unlock()
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
 
-	calls := cs.expected[key]
-	for i, c := range calls {
-		if c == call {
-			// maintain order for remaining calls
-			cs.expected[key] = append(calls[:i], calls[i+1:]...)
-			cs.exhausted[key] = append(cs.exhausted[key], call)
-			break
+	l := cs.calls[key]
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !containsCall(l.expected, call) {
+		// call is not expected anymore.
+		return
+	}
+	l.expected = removeCall(l.expected, call)
+	cs.override(key, l)
+	l.expected = append(l.expected, call)
+}
+
+// override removes the expected calls of l, the calls of key. The calls
+// expected before the innermost scope are restored when it is popped. Both
+// cs.mu and l.mu must be held.
+func (cs *callSet) override(key callSetKey, l *callList) {
+	calls := l.expected
+	l.expected = make([]*Call, 0)
+	if len(cs.scopes) == 0 {
+		return
+	}
+	scope := cs.scopes[len(cs.scopes)-1]
+	for _, call := range calls {
+		if !containsCall(scope.added, call) {
+			scope.overridden[key] = append(scope.overridden[key], call)
 		}
 	}
 }
 
+// PushScope starts a scope of expectations, undone by PopScope.
+func (cs *callSet) PushScope() {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	cs.scopes = append(cs.scopes, &callScope{overridden: make(map[callSetKey][]*Call)})
+}
+
+// PopScope removes the calls added in the innermost scope and restores the
+// calls overridden in it. It returns the removed calls that are not
+// satisfied, or false if there is no scope.
+func (cs *callSet) PopScope() ([]*Call, bool) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if len(cs.scopes) == 0 {
+		return nil, false
+	}
+	scope := cs.scopes[len(cs.scopes)-1]
+	cs.scopes = cs.scopes[:len(cs.scopes)-1]
+
+	var failures []*Call
+	for _, call := range scope.added {
+		if !call.satisfied() {
+			failures = append(failures, call)
+		}
+		l := cs.calls[callSetKey{call.receiver, call.method}]
+		if l == nil {
+			continue
+		}
+		l.mu.Lock()
+		l.expected = removeCall(l.expected, call)
+		l.exhausted = removeCall(l.exhausted, call)
+		l.mu.Unlock()
+	}
+	for key, calls := range scope.overridden {
+		l := cs.calls[key]
+		if l == nil {
+			l = &callList{}
+			cs.calls[key] = l
+		}
+		l.mu.Lock()
+		l.expected = append(calls, l.expected...)
+		l.mu.Unlock()
+	}
+	return failures, true
+}
+
+// Remove removes an expected call.
+func (cs *callSet) Remove(call *Call) {
+	l := cs.list(callSetKey{call.receiver, call.method})
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.remove(call)
+}
+
+// remove moves call from the expected to the exhausted calls. l.mu must be
+// held.
+func (l *callList) remove(call *Call) {
+	if containsCall(l.expected, call) {
+		l.expected = removeCall(l.expected, call)
+		l.exhausted = append(l.exhausted, call)
+	}
+}
+
 // FindMatch searches for a matching call. Returns error with explanation message if no call matched.
-func (cs callSet) FindMatch(receiver any, method string, args []any) (*Call, error) {
-	key := callSetKey{receiver, method}
+func (cs *callSet) FindMatch(receiver any, method string, args []any) (*Call, error) {
+	l := cs.list(callSetKey{receiver, method})
+	if l == nil {
+		return nil, fmt.Errorf("there are no expected calls of the method %q for that receiver", method)
+	}
+	return l.findMatch(method, args, nil)
+}
+
+// Match searches for a matching call and records that it was made. It
+// returns the actions of the call, to be run without holding any lock, or an
+// error with explanation message if no call matched.
+func (cs *callSet) Match(receiver any, method string, args []any) ([]func([]any) []any, error) {
+	l := cs.list(callSetKey{receiver, method})
+	if l == nil {
+		return nil, fmt.Errorf("there are no expected calls of the method %q for that receiver", method)
+	}
+
+	var actions []func([]any) []any
+	var preReqCalls []*Call
+	_, err := l.findMatch(method, args, func(expected *Call) {
+		// Two things happen here:
+		// * the matching call no longer needs to check prerequisite calls,
+		// * and the prerequisite calls are no longer expected, so remove them.
+		// They are marked as superseded before the call is counted, so that
+		// they never match once it is.
+		preReqCalls = expected.dropPrereqs()
+		for _, preReqCall := range preReqCalls {
+			preReqCall.superseded.Store(true)
+		}
+		actions = expected.call()
+		if expected.exhausted() {
+			l.remove(expected)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	cs.expectedMu.Lock()
-	defer cs.expectedMu.Unlock()
+	// The prerequisite calls may be calls of other methods, guarded by other
+	// locks, which are not acquired while holding l.mu to avoid deadlocks.
+	for _, preReqCall := range preReqCalls {
+		cs.Remove(preReqCall)
+	}
+	return actions, nil
+}
+
+// findMatch searches for a matching call in l, and calls claim, if not nil,
+// with it while holding l.mu. The arguments are matched without holding l.mu,
+// since matchers may call mocks, including this method.
+func (l *callList) findMatch(method string, args []any, claim func(*Call)) (*Call, error) {
+	l.mu.Lock()
+	expected, exhausted := l.expected, l.exhausted
+	l.mu.Unlock()
 
 	// Search through the expected calls.
-	expected := cs.expected[key]
 	var callsErrors bytes.Buffer
 	for _, call := range expected {
-		err := call.matches(args)
+		if err := call.matchesArgs(args); err != nil {
+			_, _ = fmt.Fprintf(&callsErrors, "\n%v", err)
+			continue
+		}
+		l.mu.Lock()
+		err := call.matchesState()
+		if err == nil && (!containsCall(l.expected, call) || call.superseded.Load()) {
+			// The call was removed, or is about to be, while its arguments
+			// were matched.
+			err = fmt.Errorf("expected call at %s is no longer expected", call.origin)
+		}
+		if err == nil && claim != nil {
+			claim(call)
+		}
+		l.mu.Unlock()
 		if err != nil {
 			_, _ = fmt.Fprintf(&callsErrors, "\n%v", err)
-		} else {
-			return call, nil
+			continue
 		}
+		return call, nil
 	}
 
 	// If we haven't found a match then search through the exhausted calls so we
 	// get useful error messages.
-	exhausted := cs.exhausted[key]
 	for _, call := range exhausted {
-		if err := call.matches(args); err != nil {
+		err := call.matchesArgs(args)
+		if err == nil {
+			l.mu.Lock()
+			err = call.matchesState()
+			l.mu.Unlock()
+		}
+		if err != nil {
 			_, _ = fmt.Fprintf(&callsErrors, "\n%v", err)
 			continue
 		}
@@ -132,33 +328,82 @@ func (cs callSet) FindMatch(receiver any, method string, args []any) (*Call, err
 }
 
 // Failures returns the calls that are not satisfied.
-func (cs callSet) Failures() []*Call {
-	cs.expectedMu.Lock()
-	defer cs.expectedMu.Unlock()
+func (cs *callSet) Failures() []*Call {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
 
-	failures := make([]*Call, 0, len(cs.expected))
-	for _, calls := range cs.expected {
-		for _, call := range calls {
+	failures := make([]*Call, 0, len(cs.calls))
+	for _, l := range cs.calls {
+		l.mu.Lock()
+		for _, call := range l.expected {
 			if !call.satisfied() {
 				failures = append(failures, call)
 			}
 		}
+		l.mu.Unlock()
 	}
 	return failures
 }
 
 // Satisfied returns true in case all expected calls in this callSet are satisfied.
-func (cs callSet) Satisfied() bool {
-	cs.expectedMu.Lock()
-	defer cs.expectedMu.Unlock()
+func (cs *callSet) Satisfied() bool {
+	return len(cs.Failures()) == 0
+}
 
-	for _, calls := range cs.expected {
-		for _, call := range calls {
-			if !call.satisfied() {
-				return false
+// Clear removes the expected and exhausted calls of receivers, or of all
+// receivers if none is given. They are also forgotten by the open scopes, so
+// that popping them neither restores nor reports them.
+func (cs *callSet) Clear(receivers ...any) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	cleared := func(receiver any) bool {
+		return len(receivers) == 0 || containsReceiver(receivers, receiver)
+	}
+	for key := range cs.calls {
+		if cleared(key.receiver) {
+			delete(cs.calls, key)
+		}
+	}
+	for _, scope := range cs.scopes {
+		scope.added = slices.DeleteFunc(scope.added, func(call *Call) bool {
+			return cleared(call.receiver)
+		})
+		for key := range scope.overridden {
+			if cleared(key.receiver) {
+				delete(scope.overridden, key)
 			}
 		}
 	}
+}
 
-	return true
+func containsCall(calls []*Call, call *Call) bool {
+	for _, c := range calls {
+		if c == call {
+			return true
+		}
+	}
+	return false
+}
+
+// removeCall returns calls without call, in a new slice if call is among
+// them: calls itself is not modified, since it may be iterated over
+// concurrently.
+func removeCall(calls []*Call, call *Call) []*Call {
+	for i, c := range calls {
+		if c == call {
+			// maintain order for remaining calls
+			return append(slices.Clip(calls[:i]), calls[i+1:]...)
+		}
+	}
+	return calls
+}
+
+func containsReceiver(receivers []any, receiver any) bool {
+	for _, r := range receivers {
+		if r == receiver {
+			return true
+		}
+	}
+	return false
 }
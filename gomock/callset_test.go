@@ -16,6 +16,10 @@ package gomock
 
 import (
 	"reflect"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
 )
 
@@ -48,13 +52,13 @@ func TestCallSetAdd_WhenOverridable_ClearsPreviousExpectedAndExhausted(t *testin
 	cs := newOverridableCallSet()
 
 	cs.Add(newCall(t, receiver, method, reflect.TypeOf(receiverType{}.Func)))
-	numExpectedCalls := len(cs.expected[callSetKey{receiver, method}])
+	numExpectedCalls := len(cs.calls[callSetKey{receiver, method}].expected)
 	if numExpectedCalls != 1 {
 		t.Fatalf("Expected 1 expected call in callset, got %d", numExpectedCalls)
 	}
 
 	cs.Add(newCall(t, receiver, method, reflect.TypeOf(receiverType{}.Func)))
-	newNumExpectedCalls := len(cs.expected[callSetKey{receiver, method}])
+	newNumExpectedCalls := len(cs.calls[callSetKey{receiver, method}].expected)
 	if newNumExpectedCalls != 1 {
 		t.Fatalf("Expected 1 expected call in callset, got %d", newNumExpectedCalls)
 	}
@@ -70,7 +74,7 @@ func TestCallSetRemove(t *testing.T) {
 	numCalls := 10
 	for i := 0; i < numCalls; i++ {
 		// NOTE: abuse the `numCalls` value to convey initial ordering of mocked calls
-		generatedCall := &Call{receiver: receiver, method: method, numCalls: i}
+		generatedCall := &Call{receiver: receiver, method: method, numCalls: int64(i)}
 		cs.Add(generatedCall)
 		ourCalls = append(ourCalls, generatedCall)
 	}
@@ -78,7 +82,7 @@ func TestCallSetRemove(t *testing.T) {
 	// validateOrder validates that the calls in the array are ordered as they were added
 	validateOrder := func(calls []*Call) {
 		// lastNum tracks the last `numCalls` (call order) value seen
-		lastNum := -1
+		lastNum := int64(-1)
 		for _, c := range calls {
 			if lastNum >= c.numCalls {
 				t.Errorf("found call %d after call %d", c.numCalls, lastNum)
@@ -88,7 +92,7 @@ func TestCallSetRemove(t *testing.T) {
 	}
 
 	for _, c := range ourCalls {
-		validateOrder(cs.expected[callSetKey{receiver, method}])
+		validateOrder(cs.calls[callSetKey{receiver, method}].expected)
 		cs.Remove(c)
 	}
 }
@@ -101,8 +105,8 @@ func TestCallSetFindMatch(t *testing.T) {
 		args := []any{}
 
 		c1 := newCall(t, receiver, method, reflect.TypeOf(receiverType{}.Func))
-		cs.exhausted = map[callSetKey][]*Call{
-			{receiver: receiver, fname: method}: {c1},
+		cs.calls = map[callSetKey]*callList{
+			{receiver: receiver, fname: method}: {exhausted: []*Call{c1}},
 		}
 
 		_, err := cs.FindMatch(receiver, method, args)
@@ -115,3 +119,98 @@ func TestCallSetFindMatch(t *testing.T) {
 		}
 	})
 }
+
+func TestCallSetMatch_Concurrent(t *testing.T) {
+	method := "TestMethod"
+	receivers := []any{"TestReceiver1", "TestReceiver2"}
+	cs := newCallSet()
+
+	const numCalls = 100
+	for _, receiver := range receivers {
+		cs.Add(newCall(t, receiver, method, reflect.TypeOf(receiverType{}.Func)).Times(numCalls))
+	}
+
+	var wg sync.WaitGroup
+	var failed atomic.Int64
+	for i := 0; i < numCalls+10; i++ {
+		for _, receiver := range receivers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := cs.Match(receiver, method, []any{}); err != nil {
+					failed.Add(1)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	if got, want := failed.Load(), int64(10*len(receivers)); got != want {
+		t.Errorf("got %d failed calls, want %d", got, want)
+	}
+	if failures := cs.Failures(); len(failures) != 0 {
+		t.Errorf("got unsatisfied calls %v", failures)
+	}
+}
+
+func TestCallSetMatch_PrerequisitesRemovedBeforeCall(t *testing.T) {
+	const receiver = "TestReceiver"
+	methodType := reflect.TypeOf(receiverType{}.Func)
+	cs := newCallSet()
+	first := newCall(t, receiver, "First", methodType).AnyTimes()
+	second := newCall(t, receiver, "Second", methodType).After(first)
+	cs.Add(first)
+	cs.Add(second)
+
+	// Holding the lock of the calls of First delays their removal by the call
+	// of Second, but First must no longer match once Second is counted.
+	l := cs.list(callSetKey{receiver, "First"})
+	l.mu.Lock()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := cs.Match(receiver, "Second", []any{}); err != nil {
+			t.Errorf("Second: %v", err)
+		}
+	}()
+	for !second.satisfied() {
+		runtime.Gosched()
+	}
+	if containsCall(l.expected, first) && !first.superseded.Load() {
+		t.Error("First is still expected after a call declared to follow it")
+	}
+	l.mu.Unlock()
+	<-done
+
+	if _, err := cs.Match(receiver, "First", []any{}); err == nil {
+		t.Error("First matched after a call declared to follow it")
+	}
+}
+
+func TestCallListRemove_KeepsSnapshots(t *testing.T) {
+	const receiver = "TestReceiver"
+	methodType := reflect.TypeOf(receiverType{}.Func)
+	calls := []*Call{
+		newCall(t, receiver, "Method", methodType),
+		newCall(t, receiver, "Method", methodType),
+		newCall(t, receiver, "Method", methodType),
+	}
+	l := &callList{}
+	for _, call := range calls {
+		l.expected = append(l.expected, call)
+	}
+
+	// findMatch iterates over the slices without copying them.
+	snapshot := l.expected
+	l.remove(calls[0])
+	l.remove(calls[2])
+	if !slices.Equal(snapshot, calls) {
+		t.Errorf("snapshot modified by remove: got %v, want %v", snapshot, calls)
+	}
+	if want := []*Call{calls[1]}; !slices.Equal(l.expected, want) {
+		t.Errorf("got expected calls %v, want %v", l.expected, want)
+	}
+	if want := []*Call{calls[0], calls[2]}; !slices.Equal(l.exhausted, want) {
+		t.Errorf("got exhausted calls %v, want %v", l.exhausted, want)
+	}
+}
@@ -16,10 +16,14 @@ package gomock
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"runtime"
+	"strings"
 	"sync"
+	"testing"
+	"time"
 )
 
 // A TestReporter is something that can be used to report test failures.  It
@@ -48,7 +52,9 @@ type cleanuper interface {
 // A Controller represents the top-level control of a mock ecosystem.  It
 // defines the scope and lifetime of mock objects, as well as their
 // expectations.  It is safe to call Controller's methods from multiple
-// goroutines. Each test should create a new Controller.
+// goroutines: the calls of different methods or mocks are matched
+// concurrently, and actions run without holding any lock. Each test should
+// create a new Controller.
 //
 //	func TestFoo(t *testing.T) {
 //	  ctrl := gomock.NewController(t)
@@ -72,9 +78,21 @@ type Controller struct {
 	// If the TestReporter does not implement a TestHelper it will be wrapped
 	// with a nopTestHelper.
 	T             TestHelper
-	mu            sync.Mutex
+	mu            sync.RWMutex
 	expectedCalls *callSet
 	finished      bool
+
+	defaultReturns defaultReturns
+	onUnexpected   func(UnexpectedCall) []any
+
+	parent *Controller // the controller of which this is a sub-controller
+	sub    *Controller // the unfinished sub-controller, if any
+
+	inFlight    inFlightCalls // the calls in progress while ctrl is active
+	gracePeriod time.Duration // how long finish waits for calls in progress
+
+	lateCalls []string // the calls after finish, reported by the cleanup
+	cleanedUp bool     // whether the cleanup registered with T has run, or T has none
 }
 
 // NewController returns a new Controller. It is the preferred way to create a Controller.
@@ -98,11 +116,93 @@ func NewController(t TestReporter, opts ...ControllerOption) *Controller {
 			ctrl.T.Helper()
 			ctrl.finish(true, nil)
 		})
+	} else {
+		ctrl.cleanedUp = true
 	}
 
 	return ctrl
 }
 
+// Sub returns a sub-controller of ctrl reporting failures to t, typically the
+// T of a subtest using the mocks of ctrl:
+//
+//	t.Run("not found", func(t *testing.T) {
+//	  ctrl := ctrl.Sub(t)
+//	  mock.EXPECT().Get("key").Return("", ErrNotFound)
+//	  // ...
+//	})
+//
+// Until the sub-controller is finished, the expectations recorded with the
+// mocks of ctrl are added to it, and the calls of the mocks are matched against
+// its expectations first, then against those of ctrl. Its expectations are
+// verified when t and its subtests complete, or by [Controller.Finish].
+//
+// A controller has at most one active sub-controller at a time: subtests
+// sharing mocks through Sub must not run in parallel, and nested subtests
+// call Sub on the sub-controller of their parent. Sub fails t otherwise.
+func (ctrl *Controller) Sub(t TestReporter) *Controller {
+	h, ok := t.(TestHelper)
+	if !ok {
+		h = &nopTestHelper{t}
+	}
+	h.Helper()
+	sub := &Controller{
+		T:             h,
+		expectedCalls: newCallSet(),
+		parent:        ctrl,
+		gracePeriod:   ctrl.gracePeriod,
+	}
+
+	ctrl.mu.Lock()
+	active := ctrl.sub != nil
+	if !active {
+		sub.expectedCalls.allowOverride = ctrl.expectedCalls.allowOverride
+		ctrl.sub = sub
+	}
+	ctrl.mu.Unlock()
+	if active {
+		h.Fatalf("gomock: Controller.Sub called while another sub-controller of the controller is active; " +
+			"subtests sharing mocks must not run in parallel, and nested subtests must call Sub on the sub-controller of their parent")
+		panic("unreachable")
+	}
+
+	if c, ok := isCleanuper(sub.T); ok {
+		c.Cleanup(func() {
+			sub.T.Helper()
+			sub.finish(true, nil)
+		})
+	} else {
+		sub.cleanedUp = true
+	}
+	return sub
+}
+
+// innermost returns the innermost unfinished sub-controller of ctrl, or ctrl.
+func (ctrl *Controller) innermost() *Controller {
+	c := ctrl
+	for {
+		c.mu.RLock()
+		sub := c.sub
+		c.mu.RUnlock()
+		if sub == nil {
+			return c
+		}
+		c = sub
+	}
+}
+
+// detach removes ctrl as the sub-controller of its parent.
+func (ctrl *Controller) detach() {
+	if ctrl.parent == nil {
+		return
+	}
+	ctrl.parent.mu.Lock()
+	defer ctrl.parent.mu.Unlock()
+	if ctrl.parent.sub == ctrl {
+		ctrl.parent.sub = nil
+	}
+}
+
 // ControllerOption configures how a Controller should behave.
 type ControllerOption interface {
 	apply(*Controller)
@@ -111,7 +211,8 @@ type ControllerOption interface {
 type overridableExpectationsOption struct{}
 
 // WithOverridableExpectations allows for overridable call expectations
-// i.e., subsequent call expectations override existing call expectations
+// i.e., subsequent call expectations override existing call expectations.
+// See [Call.Override] to override specific call expectations instead.
 func WithOverridableExpectations() overridableExpectationsOption {
 	return overridableExpectationsOption{}
 }
@@ -120,6 +221,24 @@ func (o overridableExpectationsOption) apply(ctrl *Controller) {
 	ctrl.expectedCalls = newOverridableCallSet()
 }
 
+type finishGracePeriodOption time.Duration
+
+// WithFinishGracePeriod makes [Controller.Finish], and the cleanup registered
+// by [NewController], wait up to d for the calls of mocks in progress, such as
+// calls from goroutines started by the test, to complete before verifying the
+// expectations. The calls still in progress after d are reported.
+//
+// Sub-controllers (see [Controller.Sub]) inherit the grace period of their
+// parent, and wait for the calls made while they are active, whichever
+// controller the mocks were created with.
+func WithFinishGracePeriod(d time.Duration) ControllerOption {
+	return finishGracePeriodOption(d)
+}
+
+func (o finishGracePeriodOption) apply(ctrl *Controller) {
+	ctrl.gracePeriod = time.Duration(o)
+}
+
 type cancelReporter struct {
 	t      TestHelper
 	cancel func()
@@ -182,61 +301,167 @@ func (ctrl *Controller) RecordCall(receiver any, method string, args ...any) *Ca
 func (ctrl *Controller) RecordCallWithMethodType(receiver any, method string, methodType reflect.Type, args ...any) *Call {
 	ctrl.T.Helper()
 
-	call := newCall(ctrl.T, receiver, method, methodType, args...)
-
-	ctrl.mu.Lock()
-	defer ctrl.mu.Unlock()
-	ctrl.expectedCalls.Add(call)
+	target := ctrl.innermost()
+	call := newCall(target.T, receiver, method, methodType, args...)
+	call.ctrl = ctrl
+	call.callSet = target.expectedCalls
+	target.expectedCalls.Add(call)
 
 	return call
 }
 
 // Call is called by a mock. It should not be called by user code.
 func (ctrl *Controller) Call(receiver any, method string, args ...any) []any {
+	// callerInfo's skip should be updated if the number of calls between the user's test
+	// and this line changes, i.e. this code is wrapped in another anonymous function.
+	// 0 is us, i.e. controller.Call(), 1 is the generated mock, and 2 is the user's test.
+	if ctrl.isFinished() {
+		if rets, ok := ctrl.lateCall(receiver, method, args, callerInfo(2)); ok {
+			return rets
+		}
+	}
 	ctrl.T.Helper()
 
-	// Nest this code so we can use defer to make sure the lock is released.
-	actions := func() []func([]any) []any {
-		ctrl.T.Helper()
-		ctrl.mu.Lock()
-		defer ctrl.mu.Unlock()
-
-		expected, err := ctrl.expectedCalls.FindMatch(receiver, method, args)
-		if err != nil {
-			// callerInfo's skip should be updated if the number of calls between the user's test
-			// and this line changes, i.e. this code is wrapped in another anonymous function.
-			// 0 is us, 1 is controller.Call(), 2 is the generated mock, and 3 is the user's test.
-			origin := callerInfo(3)
-			stringArgs := make([]string, len(args))
-			for i, arg := range args {
-				stringArgs[i] = getString(arg)
-			}
-			ctrl.T.Fatalf("Unexpected call to %T.%v(%v) at %s because: %s", receiver, method, stringArgs, origin, err)
+	// The call is in progress for the innermost sub-controller, whose
+	// expectations it may match, and for its parents.
+	active := ctrl.innermost()
+	for c := active; c != nil; c = c.parent {
+		c.inFlight.add(1)
+	}
+	defer func() {
+		for c := active; c != nil; c = c.parent {
+			c.inFlight.add(-1)
 		}
+	}()
 
-		// Two things happen here:
-		// * the matching call no longer needs to check prerequisite calls,
-		// * and the prerequisite calls are no longer expected, so remove them.
-		preReqCalls := expected.dropPrereqs()
-		for _, preReqCall := range preReqCalls {
-			ctrl.expectedCalls.Remove(preReqCall)
+	actions, err := ctrl.match(receiver, method, args)
+	if err != nil {
+		if f := ctrl.unexpectedHandler(); f != nil {
+			return ctrl.handleUnexpected(f, receiver, method, args, err)
 		}
+		origin := callerInfo(2)
+		ctrl.innermost().T.Fatalf("Unexpected call to %T.%v(%v) at %s because: %s", receiver, method, stringArgs(args), origin, err)
+	}
 
-		actions := expected.call()
-		if expected.exhausted() {
-			ctrl.expectedCalls.Remove(expected)
+	var rets []any
+	for _, action := range actions {
+		if r := action(args); r != nil {
+			rets = r
 		}
-		return actions
-	}()
+	}
+
+	return rets
+}
+
+// lateCall handles a call made at origin after ctrl finished, which likely
+// comes from a goroutine the test did not wait for. The test of a
+// [testing.TB] may have completed, so ctrl.T is not used: the call is
+// reported to the nearest unfinished parent of ctrl, or by the cleanup of
+// ctrl if it has not run yet, and returns the results of the matching
+// expected call, if any. Otherwise lateCall panics, since no test can report
+// the call any more.
+//
+// Other TestReporters outlive the controller: if neither is possible, the
+// call is reported to ctrl.T with Errorf, which can be called from any
+// goroutine. If ctrl.T has a Cleanup method, the call is made after the
+// cleanup of ctrl, typically by a later cleanup of the test, so lateCall
+// returns false to let Call match it as usual.
+func (ctrl *Controller) lateCall(receiver any, method string, args []any, origin string) ([]any, bool) {
+	msg := fmt.Sprintf("Unexpected call to %T.%v(%v) at %s because: the controller has already finished, the call may come from a leaked goroutine",
+		receiver, method, stringArgs(args), origin)
+	if !ctrl.reportLateCall(msg) {
+		if _, ok := unwrapTestReporter(ctrl.T).(testing.TB); ok {
+			panic("gomock: " + msg + "; the test has completed, so the call cannot be reported as a test failure")
+		}
+		ctrl.T.Errorf("%s", msg)
+		if _, ok := isCleanuper(ctrl.T); ok {
+			return nil, false
+		}
+	}
 
+	actions, err := ctrl.match(receiver, method, args)
+	if err != nil {
+		return ctrl.defaultResults(receiver, method), true
+	}
 	var rets []any
 	for _, action := range actions {
 		if r := action(args); r != nil {
 			rets = r
 		}
 	}
+	return rets, true
+}
 
-	return rets
+// reportLateCall reports msg, describing a call after ctrl finished, to the
+// nearest unfinished parent of ctrl, or records it for the cleanup of ctrl. It
+// returns false if neither is possible.
+func (ctrl *Controller) reportLateCall(msg string) bool {
+	for p := ctrl.parent; p != nil; p = p.parent {
+		// The lock keeps p from finishing, and its test from completing,
+		// while the call is reported.
+		p.mu.RLock()
+		if !p.finished {
+			p.T.Errorf("%s", msg)
+			p.mu.RUnlock()
+			return true
+		}
+		p.mu.RUnlock()
+	}
+
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+	if ctrl.cleanedUp {
+		return false
+	}
+	ctrl.lateCalls = append(ctrl.lateCalls, msg)
+	return true
+}
+
+// reportLateCalls reports the calls recorded after ctrl finished. It is
+// called by the cleanup of ctrl, after which the later calls can no longer be
+// reported to ctrl.T.
+func (ctrl *Controller) reportLateCalls() {
+	ctrl.T.Helper()
+
+	ctrl.mu.Lock()
+	lateCalls := ctrl.lateCalls
+	ctrl.lateCalls = nil
+	ctrl.cleanedUp = true
+	ctrl.mu.Unlock()
+	for _, msg := range lateCalls {
+		ctrl.T.Errorf("%s", msg)
+	}
+}
+
+// isFinished returns whether ctrl has finished.
+func (ctrl *Controller) isFinished() bool {
+	ctrl.mu.RLock()
+	defer ctrl.mu.RUnlock()
+	return ctrl.finished
+}
+
+// stringArgs formats the arguments of a call.
+func stringArgs(args []any) []string {
+	stringArgs := make([]string, len(args))
+	for i, arg := range args {
+		stringArgs[i] = getString(arg)
+	}
+	return stringArgs
+}
+
+// match finds the expected call matching a call among the expectations of
+// the innermost sub-controller of ctrl, then of its parents, and returns its
+// actions.
+func (ctrl *Controller) match(receiver any, method string, args []any) ([]func([]any) []any, error) {
+	var errs []string
+	for c := ctrl.innermost(); c != nil; c = c.parent {
+		actions, err := c.expectedCalls.Match(receiver, method, args)
+		if err == nil {
+			return actions, nil
+		}
+		errs = append(errs, err.Error())
+	}
+	return nil, errors.New(strings.Join(errs, "\n"))
 }
 
 // Finish checks to see if all the methods that were expected to be called were called.
@@ -259,26 +484,106 @@ func (ctrl *Controller) Satisfied() bool {
 	return ctrl.expectedCalls.Satisfied()
 }
 
+// Checkpoint checks that the expected calls of mocks, or of all the mocks of
+// the Controller if none is given, have been made so far, reporting the calls
+// that are missing at the caller's location. It then clears their
+// expectations, including those overridden in the open scopes (see
+// [Controller.PushScope]), so that the test can go on with a fresh set:
+//
+//	mock.EXPECT().Open().Return(nil)
+//	setUp(mock)
+//	ctrl.Checkpoint()
+//
+//	mock.EXPECT().Read(gomock.Any()).Return(0, io.EOF)
+//	run(mock)
+//
+// Unlike [Controller.Finish], Checkpoint does not abort the test and may be
+// called any number of times. While a sub-controller of ctrl is active (see
+// [Controller.Sub]), Checkpoint checks and clears its expectations instead.
+func (ctrl *Controller) Checkpoint(mocks ...any) {
+	ctrl.T.Helper()
+
+	target := ctrl.innermost()
+	failures := target.expectedCalls.Failures()
+	target.expectedCalls.Clear(mocks...)
+
+	// The failures are reported without holding any lock, since formatting
+	// them may call mocks.
+	origin := callerInfo(1)
+	for _, call := range failures {
+		if len(mocks) == 0 || containsReceiver(mocks, call.receiver) {
+			target.T.Errorf("missing call(s) to %v at checkpoint %s", call, origin)
+		}
+	}
+}
+
+// PushScope starts a scope of expectations, which ends with the matching
+// [Controller.PopScope]. The expectations recorded in the scope, and the
+// expectations overridden in it with [Call.Override], are undone when it
+// ends. This lets a subtest temporarily replace the expectations set up by
+// the test:
+//
+//	t.Run("not found", func(t *testing.T) {
+//	  ctrl.PushScope()
+//	  defer ctrl.PopScope()
+//	  mock.EXPECT().Get("key").Return("", ErrNotFound).Override()
+//	  // ...
+//	})
+//
+// Scopes can be nested. Like the expectations, scopes are those of the
+// active sub-controller of ctrl, if any (see [Controller.Sub]).
+func (ctrl *Controller) PushScope() {
+	ctrl.innermost().expectedCalls.PushScope()
+}
+
+// PopScope ends the innermost scope started with [Controller.PushScope]. It
+// reports the calls expected in the scope that are missing, removes them and
+// restores the expectations overridden in the scope.
+func (ctrl *Controller) PopScope() {
+	ctrl.T.Helper()
+
+	target := ctrl.innermost()
+	failures, ok := target.expectedCalls.PopScope()
+	if !ok {
+		target.T.Fatalf("Controller.PopScope was called without a matching PushScope")
+		return
+	}
+	origin := callerInfo(1)
+	for _, call := range failures {
+		target.T.Errorf("missing call(s) to %v at end of scope %s", call, origin)
+	}
+}
+
 func (ctrl *Controller) finish(cleanup bool, panicErr any) {
 	ctrl.T.Helper()
 
+	defer ctrl.detach()
+	if cleanup {
+		defer ctrl.reportLateCalls()
+	}
+	if ctrl.gracePeriod > 0 && panicErr == nil && !ctrl.isFinished() {
+		ctrl.waitInFlight()
+	}
+
 	ctrl.mu.Lock()
-	defer ctrl.mu.Unlock()
+	finished := ctrl.finished
+	ctrl.finished = true
+	ctrl.mu.Unlock()
 
-	if ctrl.finished {
+	if finished {
 		if _, ok := isCleanuper(ctrl.T); !ok {
 			ctrl.T.Fatalf("Controller.Finish was called more than once. It has to be called exactly once.")
 		}
 		return
 	}
-	ctrl.finished = true
 
 	// Short-circuit, pass through the panic.
 	if panicErr != nil {
 		panic(panicErr)
 	}
 
-	// Check that all remaining expected calls are satisfied.
+	// Check that all remaining expected calls are satisfied. The failures are
+	// reported without holding any lock, since formatting them may call mocks.
 	failures := ctrl.expectedCalls.Failures()
 	for _, call := range failures {
 		ctrl.T.Errorf("missing call(s) to %v", call)
@@ -292,6 +597,54 @@ func (ctrl *Controller) finish(cleanup bool, panicErr any) {
 	}
 }
 
+// waitInFlight waits up to ctrl.gracePeriod for the calls in progress to
+// complete, and reports those which do not.
+func (ctrl *Controller) waitInFlight() {
+	ctrl.T.Helper()
+
+	if n := ctrl.inFlight.wait(ctrl.gracePeriod); n > 0 {
+		ctrl.T.Errorf("%d call(s) to mocks still in progress after waiting %v, they may come from leaked goroutines", n, ctrl.gracePeriod)
+	}
+}
+
+// inFlightCalls counts the calls of mocks in progress.
+type inFlightCalls struct {
+	mu   sync.Mutex
+	n    int
+	done *sync.Cond // broadcast when n drops to 0 or a wait expires
+}
+
+func (c *inFlightCalls) add(delta int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.n += delta
+	if c.n == 0 && c.done != nil {
+		c.done.Broadcast()
+	}
+}
+
+// wait waits up to d for the calls in progress to complete, and returns the
+// number of calls still in progress.
+func (c *inFlightCalls) wait(d time.Duration) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.done == nil {
+		c.done = sync.NewCond(&c.mu)
+	}
+	expired := false
+	timer := time.AfterFunc(d, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		expired = true
+		c.done.Broadcast()
+	})
+	defer timer.Stop()
+	for c.n > 0 && !expired {
+		c.done.Wait()
+	}
+	return c.n
+}
+
 // callerInfo returns the file:line of the call site. skip is the number
 // of stack frames to skip when reporting. 0 is callerInfo's call site.
 func callerInfo(skip int) string {
@@ -17,8 +17,12 @@ package gomock_test
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"go.uber.org/mock/gomock"
 )
@@ -928,3 +932,460 @@ func TestCallAfterLoopPanic(t *testing.T) {
 	})
 	ctrl = gomock.NewController(reporter)
 }
+
+func TestCheckpoint(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "1").AnyTimes()
+	ctrl.RecordCall(subject, "BarMethod", "2")
+	ctrl.Call(subject, "FooMethod", "1")
+	ctrl.Call(subject, "BarMethod", "2")
+	ctrl.Checkpoint()
+	reporter.assertPass("All calls made before the checkpoint.")
+
+	// The expectations of the first phase are cleared.
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "1")
+	}, "there are no expected calls of the method \"FooMethod\" for that receiver")
+	reporter.failed = false
+
+	ctrl.RecordCall(subject, "BarMethod", "3")
+	ctrl.Checkpoint()
+	reporter.assertFail("Missing call at the checkpoint.")
+	if got := reporter.log[len(reporter.log)-1]; !strings.Contains(got, "at checkpoint") || !strings.Contains(got, "controller_test.go") {
+		t.Errorf("Error message:\ngot: %q\nwant the checkpoint location", got)
+	}
+
+	// The missing call is not reported again.
+	reporter.failed = false
+	ctrl.Finish()
+	reporter.assertPass("Missing call reported at the checkpoint.")
+}
+
+func TestCheckpoint_Mocks(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	mockOne, mockTwo := NewMockFoo(ctrl), NewMockFoo(ctrl)
+
+	mockOne.EXPECT().Bar("1").Return("one")
+	mockTwo.EXPECT().Bar("2").Return("two")
+	mockOne.Bar("1")
+	ctrl.Checkpoint(mockOne)
+	reporter.assertPass("Only the expectations of mockOne are checked.")
+
+	// The expectations of mockTwo are kept.
+	assertEqual(t, "two", mockTwo.Bar("2"))
+	ctrl.Finish()
+	reporter.assertPass("All calls made.")
+}
+
+func TestSub(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+	ctrl.RecordCall(subject, "FooMethod", "1").Return(1).AnyTimes()
+
+	subReporter := NewErrorReporter(t)
+	sub := ctrl.Sub(subReporter)
+	// Expectations recorded with the mocks of ctrl are added to sub.
+	ctrl.RecordCall(subject, "FooMethod", "1").Return(2)
+	ctrl.RecordCall(subject, "BarMethod", "2")
+
+	assertEqual(t, []any{2}, ctrl.Call(subject, "FooMethod", "1"))
+	// Calls fall back to the expectations of ctrl.
+	assertEqual(t, []any{1}, ctrl.Call(subject, "FooMethod", "1"))
+
+	subReporter.assertFatal(func() {
+		sub.Finish()
+	}, "aborting test due to missing call(s)")
+	reporter.assertPass("Failures of the sub-controller are reported to its reporter.")
+
+	// Once sub is finished, its expectations are gone.
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "BarMethod", "2")
+	}, "Unexpected call to", "there are no expected calls of the method \"BarMethod\" for that receiver")
+}
+
+func TestSub_UnexpectedCall(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	subReporter := NewErrorReporter(t)
+	sub := ctrl.Sub(subReporter)
+	subReporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "1")
+	}, "Unexpected call to")
+	sub.Finish()
+	reporter.assertPass("Unexpected calls are reported to the sub-controller's reporter.")
+}
+
+func TestSub_Cleanup(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	t.Run("subtest", func(t *testing.T) {
+		subReporter := NewErrorReporter(t)
+		t.Cleanup(func() {
+			subReporter.assertFail("Missing call reported at the subtest's cleanup.")
+		})
+		ctrl.Sub(subReporter)
+		ctrl.RecordCall(subject, "FooMethod", "1")
+	})
+
+	ctrl.Finish()
+	reporter.assertPass("The expectations of the subtest are verified by the sub-controller.")
+}
+
+func TestSub_ScopesAndCheckpoint(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+	ctrl.RecordCall(subject, "FooMethod", "1").Return(1).AnyTimes()
+
+	subReporter := NewErrorReporter(t)
+	sub := ctrl.Sub(subReporter)
+	ctrl.RecordCall(subject, "FooMethod", "1").Return(2).AnyTimes()
+
+	// The scope is one of the sub-controller, where expectations are recorded.
+	ctrl.PushScope()
+	ctrl.RecordCall(subject, "FooMethod", "1").Return(3).Override()
+	ctrl.RecordCall(subject, "BarMethod", "2")
+	assertEqual(t, []any{3}, ctrl.Call(subject, "FooMethod", "1"))
+	ctrl.PopScope()
+	assertLastLogContains(t, subReporter, "missing call(s) to *gomock_test.Subject.BarMethod(is equal to 2 (string))", "at end of scope")
+	assertEqual(t, []any{2}, ctrl.Call(subject, "FooMethod", "1"))
+
+	// Checkpoint clears the expectations of the sub-controller only.
+	ctrl.RecordCall(subject, "BarMethod", "2")
+	ctrl.Checkpoint()
+	assertLastLogContains(t, subReporter, "missing call(s) to *gomock_test.Subject.BarMethod(is equal to 2 (string))", "at checkpoint")
+	assertEqual(t, []any{1}, ctrl.Call(subject, "FooMethod", "1"))
+
+	sub.Finish()
+	assertEqual(t, []any{1}, ctrl.Call(subject, "FooMethod", "1"))
+	ctrl.Finish()
+	reporter.assertPass("The expectations of ctrl are unaffected by the scope and checkpoint of the sub-controller.")
+}
+
+func assertLastLogContains(t *testing.T, e *ErrorReporter, wants ...string) {
+	t.Helper()
+	if len(e.log) == 0 {
+		t.Errorf("Expected a failure containing %q, but got none", wants)
+		return
+	}
+	got := e.log[len(e.log)-1]
+	for _, want := range wants {
+		if !strings.Contains(got, want) {
+			t.Errorf("Failure:\ngot: %q\nwant to contain: %q", got, want)
+		}
+	}
+}
+
+func TestSub_SecondActiveSub(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	first := ctrl.Sub(NewErrorReporter(t))
+	secondReporter := NewErrorReporter(t)
+	secondReporter.assertFatal(func() {
+		ctrl.Sub(secondReporter)
+	}, "Controller.Sub called while another sub-controller of the controller is active")
+
+	// Nested subtests use the sub-controller of their parent.
+	nestedReporter := NewErrorReporter(t)
+	nested := first.Sub(nestedReporter)
+	ctrl.RecordCall(subject, "FooMethod", "1").Return(1)
+	assertEqual(t, []any{1}, ctrl.Call(subject, "FooMethod", "1"))
+	nested.Finish()
+	nestedReporter.assertPass("Expectations recorded in the nested sub-controller.")
+
+	first.Finish()
+	// Once the first sub-controller is finished, another one can be started.
+	third := ctrl.Sub(NewErrorReporter(t))
+	third.Finish()
+	ctrl.Finish()
+	reporter.assertPass("Sequential sub-controllers.")
+}
+
+// benchReporter reports failures to a *testing.B. It does not implement
+// TestHelper, whose Helper method would serialize the calls of a benchmark.
+type benchReporter struct {
+	gomock.TestReporter
+}
+
+// A type purely for use as distinct receivers in benchmarks.
+type Counter struct {
+	id int
+}
+
+func (c *Counter) Inc(n int) int {
+	return n + 1
+}
+
+func BenchmarkCallParallel(b *testing.B) {
+	b.Run("SameMethod", func(b *testing.B) {
+		ctrl := gomock.NewController(benchReporter{b})
+		counter := &Counter{}
+		ctrl.RecordCall(counter, "Inc", gomock.Any()).Return(1).AnyTimes()
+
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				ctrl.Call(counter, "Inc", 0)
+			}
+		})
+	})
+
+	b.Run("DistinctMocks", func(b *testing.B) {
+		ctrl := gomock.NewController(benchReporter{b})
+		counters := make([]*Counter, 64)
+		for i := range counters {
+			counters[i] = &Counter{id: i}
+			ctrl.RecordCall(counters[i], "Inc", gomock.Any()).Return(1).AnyTimes()
+		}
+
+		var next atomic.Int64
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			counter := counters[int(next.Add(1))%len(counters)]
+			for pb.Next() {
+				ctrl.Call(counter, "Inc", 0)
+			}
+		})
+	})
+}
+
+func TestReentrantCall(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	// FooMethod returns the length of its argument, recursively.
+	ctrl.RecordCall(subject, "FooMethod", "").Return(0)
+	ctrl.RecordCall(subject, "FooMethod", gomock.Not("")).DoAndReturn(func(arg string) int {
+		return 1 + ctrl.Call(subject, "FooMethod", arg[1:])[0].(int)
+	}).Times(3)
+
+	assertEqual(t, []any{3}, ctrl.Call(subject, "FooMethod", "abc"))
+	ctrl.Finish()
+	reporter.assertPass("Recursive calls are matched.")
+}
+
+func TestReentrantMatcher(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "inner").Return(1)
+	ctrl.RecordCall(subject, "FooMethod", gomock.Cond(func(arg string) bool {
+		// The matcher calls the method being matched.
+		return ctrl.Call(subject, "FooMethod", "inner")[0].(int) == 1 && arg == "outer"
+	})).Return(2)
+
+	assertEqual(t, []any{2}, ctrl.Call(subject, "FooMethod", "outer"))
+	ctrl.Finish()
+	reporter.assertPass("Matchers calling mocks are evaluated.")
+}
+
+func TestRecordCallInAction(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "open").Do(func(string) {
+		ctrl.RecordCall(subject, "BarMethod", "read").Return(1)
+		ctrl.RecordCall(subject, "FooMethod", "close")
+	})
+
+	ctrl.Call(subject, "FooMethod", "open")
+	assertEqual(t, []any{1}, ctrl.Call(subject, "BarMethod", "read"))
+	ctrl.Call(subject, "FooMethod", "close")
+	ctrl.Finish()
+	reporter.assertPass("Expectations recorded in actions are matched.")
+}
+
+// stringer is a fmt.Stringer calling another, typically a mock.
+type stringer struct {
+	fmt.Stringer
+}
+
+func (s stringer) String() string {
+	return s.Stringer.String()
+}
+
+func TestStringerMockInMissingCall(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+	mockFoo := NewMockFoo(ctrl)
+	mockFoo.EXPECT().String().Return("mock foo").AnyTimes()
+
+	// Formatting the missing call calls mockFoo.String.
+	ctrl.RecordCall(subject, "FooMethod", stringer{mockFoo})
+	ctrl.Checkpoint(subject)
+	reporter.assertFail("Missing call at the checkpoint.")
+	if got := reporter.log[len(reporter.log)-1]; !strings.Contains(got, "mock foo") {
+		t.Errorf("Error message:\ngot: %q\nwant to contain: %q", got, "mock foo")
+	}
+}
+
+func TestConcurrentRecordAndCall(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	const n = 50
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			arg := strconv.Itoa(i)
+			ctrl.RecordCall(subject, "FooMethod", arg).DoAndReturn(func(string) int {
+				// Record an expectation while other goroutines record and
+				// match theirs.
+				ctrl.RecordCall(subject, "BarMethod", arg).Return(i)
+				return i
+			})
+			if got := ctrl.Call(subject, "FooMethod", arg)[0].(int); got != i {
+				t.Errorf("FooMethod(%q) = %d, want %d", arg, got, i)
+			}
+			if got := ctrl.Call(subject, "BarMethod", arg)[0].(int); got != i {
+				t.Errorf("BarMethod(%q) = %d, want %d", arg, got, i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	ctrl.Finish()
+	reporter.assertPass("Concurrent expectations are recorded and matched.")
+}
+
+func TestCallAfterFinish(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	reporter.Cleanup(func() {
+		reporter.assertFail("The call after the controller finished is reported by its cleanup.")
+		assertLastLogContains(t, reporter, "the controller has already finished", "controller_test.go")
+	})
+	ctrl := gomock.NewController(reporter)
+	mockFoo := NewMockFoo(ctrl)
+	mockFoo.EXPECT().Bar("argument").Return("result").AnyTimes()
+	ctrl.Finish()
+	reporter.assertPass("No missing calls.")
+
+	assertEqual(t, "result", mockFoo.Bar("argument"))
+	reporter.assertPass("The call is not reported before the cleanup.")
+}
+
+func TestCallAfterFinish_NoCleanup(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	// HelperReporter hides the Cleanup method of reporter.
+	ctrl := gomock.NewController(&HelperReporter{TestReporter: reporter})
+	mockFoo := NewMockFoo(ctrl)
+	mockFoo.EXPECT().Bar("expected").Return("result").AnyTimes()
+	ctrl.Finish()
+	reporter.assertPass("No missing calls.")
+
+	// The calls are reported once, without failing the test fatally, since
+	// they may come from goroutines other than the test's.
+	assertEqual(t, "result", mockFoo.Bar("expected"))
+	assertEqual(t, "", mockFoo.Bar("unexpected"))
+	reporter.assertFail("Calls after the controller finished.")
+	if len(reporter.log) != 2 {
+		t.Errorf("Got %d failures, want 2: %q", len(reporter.log), reporter.log)
+	}
+	assertLastLogContains(t, reporter, "the controller has already finished", "controller_test.go")
+}
+
+func TestCallAfterSubtest_ReportedToParent(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	var mockFoo *MockFoo
+	t.Run("subtest", func(t *testing.T) {
+		mockFoo = NewMockFoo(ctrl.Sub(t))
+		mockFoo.EXPECT().Bar("argument").Return("result").AnyTimes()
+	})
+
+	// The subtest has completed, so its T must not be used any more.
+	assertEqual(t, "result", mockFoo.Bar("argument"))
+	reporter.assertFail("The call after the subtest completed is reported to the parent controller.")
+	assertLastLogContains(t, reporter, "the controller has already finished", "controller_test.go")
+}
+
+func TestCallAfterSubtest_Panics(t *testing.T) {
+	var mockFoo *MockFoo
+	t.Run("subtest", func(t *testing.T) {
+		mockFoo = NewMockFoo(gomock.NewController(t))
+		mockFoo.EXPECT().Bar("argument").Return("result").AnyTimes()
+	})
+
+	// No test is left to report the call to.
+	defer func() {
+		got, _ := recover().(string)
+		for _, want := range []string{"gomock: Unexpected call to", "the controller has already finished", "controller_test.go"} {
+			if !strings.Contains(got, want) {
+				t.Errorf("Panic:\ngot: %q\nwant to contain: %q", got, want)
+			}
+		}
+	}()
+	mockFoo.Bar("argument")
+}
+
+func TestFinishGracePeriod(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithFinishGracePeriod(time.Minute))
+	subject := new(Subject)
+
+	started := make(chan struct{})
+	var done atomic.Bool
+	ctrl.RecordCall(subject, "FooMethod", "argument").Do(func(string) {
+		close(started)
+		time.Sleep(10 * time.Millisecond)
+		done.Store(true)
+	})
+	go ctrl.Call(subject, "FooMethod", "argument")
+
+	<-started
+	ctrl.Finish()
+	if !done.Load() {
+		t.Error("Finish returned before the call in progress completed.")
+	}
+	reporter.assertPass("The call in progress completed.")
+}
+
+func TestFinishGracePeriod_Sub(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithFinishGracePeriod(time.Minute))
+	subject := new(Subject)
+	sub := ctrl.Sub(reporter)
+
+	started := make(chan struct{})
+	var done atomic.Bool
+	ctrl.RecordCall(subject, "FooMethod", "argument").Do(func(string) {
+		close(started)
+		time.Sleep(10 * time.Millisecond)
+		done.Store(true)
+	})
+	// The call is made through the controller of the mocks, not sub.
+	go ctrl.Call(subject, "FooMethod", "argument")
+
+	<-started
+	sub.Finish()
+	if !done.Load() {
+		t.Error("Finish of the sub-controller returned before the call in progress completed.")
+	}
+	reporter.assertPass("The call in progress completed.")
+}
+
+func TestFinishGracePeriod_Expired(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithFinishGracePeriod(10*time.Millisecond))
+	subject := new(Subject)
+
+	started, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	ctrl.RecordCall(subject, "FooMethod", "argument").Do(func(string) {
+		close(started)
+		<-release
+	})
+	go ctrl.Call(subject, "FooMethod", "argument")
+
+	<-started
+	ctrl.Finish()
+	reporter.assertFail("The call is still in progress.")
+	if got, want := reporter.log[0], "1 call(s) to mocks still in progress"; !strings.Contains(got, want) {
+		t.Errorf("Error message:\ngot: %q\nwant to contain: %q", got, want)
+	}
+}
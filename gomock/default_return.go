@@ -0,0 +1,111 @@
+package gomock
+
+import (
+	"reflect"
+	"sync"
+)
+
+// defaultReturns is a registry of the values returned by calls without
+// Return or DoAndReturn, by result type. Values registered for a mock take
+// precedence over those registered for all mocks.
+type defaultReturns struct {
+	mu     sync.RWMutex
+	values map[reflect.Type]reflect.Value
+	mocks  map[any]map[reflect.Type]reflect.Value
+}
+
+func (d *defaultReturns) set(mock any, typ reflect.Type, value reflect.Value) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	values := d.values
+	if mock != nil {
+		values = d.mocks[mock]
+		if values == nil {
+			if d.mocks == nil {
+				d.mocks = make(map[any]map[reflect.Type]reflect.Value)
+			}
+			values = make(map[reflect.Type]reflect.Value)
+			d.mocks[mock] = values
+		}
+	} else if values == nil {
+		d.values = make(map[reflect.Type]reflect.Value)
+		values = d.values
+	}
+	values[typ] = value
+}
+
+// get returns the value registered as the default value of the results of
+// type typ of the methods of mock, if any.
+func (d *defaultReturns) get(mock any, typ reflect.Type) (any, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if v, ok := d.mocks[mock][typ]; ok {
+		return v.Interface(), true
+	}
+	if v, ok := d.values[typ]; ok {
+		return v.Interface(), true
+	}
+	return nil, false
+}
+
+// defaultReturn returns the default value of the results of type typ of the
+// methods of mock: the value registered with ctrl, or else with its parents
+// (see [Controller.Sub]), or else the zero value of typ.
+func (ctrl *Controller) defaultReturn(mock any, typ reflect.Type) any {
+	for c := ctrl; c != nil; c = c.parent {
+		if v, ok := c.defaultReturns.get(mock, typ); ok {
+			return v
+		}
+	}
+	return reflect.Zero(typ).Interface()
+}
+
+// typedValue returns value as a reflect.Value of type T, which is an
+// interface type if T is.
+func typedValue[T any](value T) (reflect.Type, reflect.Value) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	v := reflect.New(typ).Elem()
+	v.Set(reflect.ValueOf(&value).Elem())
+	return typ, v
+}
+
+type defaultReturnOption struct {
+	typ   reflect.Type
+	value reflect.Value
+}
+
+// WithDefaultReturn registers value as the default value of the results of
+// type T of all the mocks of the Controller. See [SetDefaultReturn].
+func WithDefaultReturn[T any](value T) ControllerOption {
+	typ, v := typedValue(value)
+	return defaultReturnOption{typ: typ, value: v}
+}
+
+func (o defaultReturnOption) apply(ctrl *Controller) {
+	ctrl.defaultReturns.set(nil, o.typ, o.value)
+}
+
+// SetDefaultReturn registers value as the default value of the results of
+// type T of all the mocks of ctrl. Calls that are not given their return
+// values with Return or DoAndReturn, such as those of AnyTimes expectations,
+// return the default values instead of zero values:
+//
+//	gomock.SetDefaultReturn[error](ctrl, ErrNotImplemented)
+//	gomock.SetDefaultReturn(ctrl, &User{Name: "fixture"})
+//
+// Results match by identical type only: a default error is not returned for
+// results of a concrete type implementing error. The values registered with
+// a sub-controller (see [Controller.Sub]) take precedence over those of its
+// parent while it is active.
+func SetDefaultReturn[T any](ctrl *Controller, value T) {
+	typ, v := typedValue(value)
+	ctrl.defaultReturns.set(nil, typ, v)
+}
+
+// SetMockDefaultReturn registers value as the default value of the results
+// of type T of mock, which is a mock of ctrl. It overrides the value
+// registered with [SetDefaultReturn] for mock.
+func SetMockDefaultReturn[T any](ctrl *Controller, mock any, value T) {
+	typ, v := typedValue(value)
+	ctrl.defaultReturns.set(mock, typ, v)
+}
@@ -0,0 +1,189 @@
+package gomock_test
+
+import (
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+type User struct {
+	Name string
+}
+
+type UserStore struct {
+	name string // so that distinct stores have distinct addresses
+}
+
+func (s *UserStore) Get(id string) (*User, error)  { return nil, nil }
+func (s *UserStore) Count() (int, error)           { return 0, nil }
+func (s *UserStore) Find(name string) (any, error) { return nil, nil }
+
+var errNotImplemented = errors.New("not implemented")
+
+func TestDefaultReturn(t *testing.T) {
+	fixture := &User{Name: "fixture"}
+	_, ctrl := createFixtures(t)
+	gomock.SetDefaultReturn[error](ctrl, errNotImplemented)
+	gomock.SetDefaultReturn(ctrl, fixture)
+
+	store := &UserStore{}
+	ctrl.RecordCall(store, "Get", gomock.Any()).AnyTimes()
+	ctrl.RecordCall(store, "Count").AnyTimes()
+
+	rets := ctrl.Call(store, "Get", "id")
+	if rets[0] != fixture || rets[1] != errNotImplemented {
+		t.Errorf("Get() = %v, %v, want the registered defaults", rets[0], rets[1])
+	}
+	rets = ctrl.Call(store, "Count")
+	if rets[0] != 0 || rets[1] != errNotImplemented {
+		t.Errorf("Count() = %v, %v, want 0 and the registered error", rets[0], rets[1])
+	}
+}
+
+func TestDefaultReturn_Overrides(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	gomock.SetDefaultReturn[error](ctrl, errNotImplemented)
+
+	store, other := &UserStore{}, &UserStore{}
+	gomock.SetMockDefaultReturn[error](ctrl, store, nil)
+	gomock.SetMockDefaultReturn(ctrl, store, 42)
+
+	ctrl.RecordCall(store, "Count").AnyTimes()
+	ctrl.RecordCall(other, "Count").AnyTimes()
+	ctrl.RecordCall(store, "Get", "explicit").Return(nil, errors.New("explicit"))
+
+	// The mock's defaults override the controller's.
+	if rets := ctrl.Call(store, "Count"); rets[0] != 42 || rets[1] != nil {
+		t.Errorf("store.Count() = %v, %v, want 42, nil", rets[0], rets[1])
+	}
+	if rets := ctrl.Call(other, "Count"); rets[0] != 0 || rets[1] != errNotImplemented {
+		t.Errorf("other.Count() = %v, %v, want 0 and the registered error", rets[0], rets[1])
+	}
+	// Return overrides the defaults.
+	if rets := ctrl.Call(store, "Get", "explicit"); rets[1].(error).Error() != "explicit" {
+		t.Errorf("store.Get() error = %v, want explicit", rets[1])
+	}
+}
+
+func TestWithDefaultReturn(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithDefaultReturn[error](errNotImplemented), gomock.WithDefaultReturn[any]("anything"))
+
+	store := &UserStore{}
+	ctrl.RecordCall(store, "Find", "name").Do(func(string) {})
+
+	rets := ctrl.Call(store, "Find", "name")
+	if rets[0] != "anything" || rets[1] != errNotImplemented {
+		t.Errorf("Find() = %v, %v, want the registered defaults", rets[0], rets[1])
+	}
+	ctrl.Finish()
+	reporter.assertPass("calls with default returns")
+}
+
+func TestOnUnexpected(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	gomock.SetDefaultReturn[error](ctrl, errNotImplemented)
+
+	store := &UserStore{}
+	ctrl.RecordCall(store, "Get", "known").Return(&User{Name: "known"}, nil)
+
+	var unexpected []gomock.UnexpectedCall
+	ctrl.OnUnexpected(func(call gomock.UnexpectedCall) []any {
+		unexpected = append(unexpected, call)
+		if call.Method == "Count" {
+			return []any{7, nil}
+		}
+		return nil
+	})
+
+	// Unexpected calls return the results of the handler, or the defaults.
+	if rets := ctrl.Call(store, "Get", "other"); rets[0] != (*User)(nil) || rets[1] != errNotImplemented {
+		t.Errorf("Get(other) = %v, %v, want the defaults", rets[0], rets[1])
+	}
+	if rets := ctrl.Call(store, "Count"); rets[0] != 7 || rets[1] != nil {
+		t.Errorf("Count() = %v, %v, want 7, nil", rets[0], rets[1])
+	}
+	// Expected calls are not affected.
+	if rets := ctrl.Call(store, "Get", "known"); rets[0].(*User).Name != "known" {
+		t.Errorf("Get(known) = %v, want the expected result", rets[0])
+	}
+
+	if len(unexpected) != 2 {
+		t.Fatalf("handler called %d times, want 2", len(unexpected))
+	}
+	if got := unexpected[0]; got.Receiver != store || got.Method != "Get" || len(got.Args) != 1 || got.Args[0] != "other" {
+		t.Errorf("unexpected call = %+v, want store.Get(other)", got)
+	}
+	if unexpected[0].Err == nil {
+		t.Error("unexpected call has no error")
+	}
+	reporter.assertPass("unexpected calls handled by OnUnexpected")
+
+	// Without a handler, unexpected calls fail again.
+	ctrl.OnUnexpected(nil)
+	reporter.assertFatal(func() {
+		ctrl.Call(store, "Count")
+	}, "Unexpected call to", "there are no expected calls of the method \"Count\" for that receiver")
+}
+
+func TestWithOnUnexpected(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithOnUnexpected(func(gomock.UnexpectedCall) []any {
+		return []any{1}
+	}))
+
+	store := &UserStore{}
+	reporter.assertFatal(func() {
+		ctrl.Call(store, "Count")
+	}, "wrong number of results returned by the OnUnexpected handler for *gomock_test.UserStore.Count: got 1, want 2")
+}
+
+func TestOnUnexpected_Sub(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	ctrl.OnUnexpected(func(gomock.UnexpectedCall) []any { return []any{1, nil} })
+
+	store := &UserStore{}
+	t.Run("sub", func(t *testing.T) {
+		sub := ctrl.Sub(t)
+		sub.OnUnexpected(func(gomock.UnexpectedCall) []any { return []any{2, nil} })
+		if rets := ctrl.Call(store, "Count"); rets[0] != 2 {
+			t.Errorf("Count() = %v, want the result of the handler of the sub-controller", rets[0])
+		}
+	})
+	if rets := ctrl.Call(store, "Count"); rets[0] != 1 {
+		t.Errorf("Count() = %v, want the result of the handler of the controller", rets[0])
+	}
+}
+
+func TestDefaultReturn_Sub(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	store, other := &UserStore{}, &UserStore{}
+	gomock.SetDefaultReturn(ctrl, 1)
+	ctrl.RecordCall(store, "Count").AnyTimes()
+	ctrl.RecordCall(other, "Count").AnyTimes()
+
+	t.Run("sub", func(t *testing.T) {
+		sub := ctrl.Sub(t)
+		gomock.SetDefaultReturn[error](sub, errNotImplemented)
+		gomock.SetMockDefaultReturn(sub, store, 2)
+		sub.RecordCall(store, "Get", "key").AnyTimes()
+
+		if rets := ctrl.Call(store, "Get", "key"); rets[1] != errNotImplemented {
+			t.Errorf("Get(key) = %v, %v, want the default error of the sub-controller", rets[0], rets[1])
+		}
+		if rets := ctrl.Call(store, "Count"); rets[0] != 2 || rets[1] != errNotImplemented {
+			t.Errorf("Count() = %v, %v, want the defaults of the sub-controller", rets[0], rets[1])
+		}
+		if rets := ctrl.Call(other, "Count"); rets[0] != 1 || rets[1] != errNotImplemented {
+			t.Errorf("Count() = %v, %v, want the defaults of the controller and of the sub-controller", rets[0], rets[1])
+		}
+		sub.OnUnexpected(func(gomock.UnexpectedCall) []any { return nil })
+		if rets := ctrl.Call(store, "Find", "name"); rets[1] != errNotImplemented {
+			t.Errorf("Find(name) = %v, %v, want the default error of the sub-controller", rets[0], rets[1])
+		}
+	})
+	if rets := ctrl.Call(store, "Count"); rets[0] != 1 || rets[1] != nil {
+		t.Errorf("Count() = %v, %v, want the defaults of the controller", rets[0], rets[1])
+	}
+}
@@ -50,6 +50,18 @@
 //	    mockObj.EXPECT().SomeMethod(3, "third"),
 //	)
 //
+// Mocks are reentrant: the actions declared with Call.Do and Call.DoAndReturn,
+// as well as matchers, may call mocks of the same Controller, including the
+// method being called, and record expectations, from any goroutine. For
+// example, a recursive method can be stubbed with an action calling the mock:
+//
+//	mockObj.EXPECT().Depth(gomock.Any()).DoAndReturn(func(n *Node) int {
+//	    if n == nil {
+//	        return 0
+//	    }
+//	    return 1 + mockObj.Depth(n.Child)
+//	}).AnyTimes()
+//
 // The standard TestReporter most users will pass to `NewController` is a
 // `*testing.T` from the context of the test. Note that this will use the
 // standard `t.Error` and `t.Fatal` methods to report what happened in the test.
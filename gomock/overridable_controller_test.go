@@ -32,3 +32,114 @@ func TestEcho_WithOverride_BaseCase(t *testing.T) {
 		t.Fatalf("expected response to equal 'bar', got %s", res)
 	}
 }
+
+func TestOverride(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockIndex := NewMockFoo(ctrl)
+	otherIndex := NewMockFoo(ctrl)
+
+	mockIndex.EXPECT().Bar(gomock.Any()).Return("foo").AnyTimes()
+	otherIndex.EXPECT().Bar(gomock.Any()).Return("other")
+	mockIndex.EXPECT().Bar(gomock.Any()).Return("bar").Override()
+
+	if res := mockIndex.Bar("input"); res != "bar" {
+		t.Fatalf("expected response to equal 'bar', got %s", res)
+	}
+	// The expectations of other mocks are kept.
+	if res := otherIndex.Bar("input"); res != "other" {
+		t.Fatalf("expected response to equal 'other', got %s", res)
+	}
+}
+
+func TestScope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockIndex := NewMockFoo(ctrl)
+
+	mockIndex.EXPECT().Bar("a").Return("foo").AnyTimes()
+	mockIndex.EXPECT().Bar("b").Return("baz").AnyTimes()
+
+	ctrl.PushScope()
+	mockIndex.EXPECT().Bar("a").Return("bar").Override()
+	if res := mockIndex.Bar("a"); res != "bar" {
+		t.Fatalf("expected response to equal 'bar', got %s", res)
+	}
+
+	ctrl.PushScope()
+	mockIndex.EXPECT().Bar("a").Return("qux").Override()
+	if res := mockIndex.Bar("a"); res != "qux" {
+		t.Fatalf("expected response to equal 'qux', got %s", res)
+	}
+	ctrl.PopScope()
+	ctrl.PopScope()
+
+	// The overridden expectations are restored.
+	if res := mockIndex.Bar("a"); res != "foo" {
+		t.Fatalf("expected response to equal 'foo', got %s", res)
+	}
+	if res := mockIndex.Bar("b"); res != "baz" {
+		t.Fatalf("expected response to equal 'baz', got %s", res)
+	}
+}
+
+func TestScope_WithOverridableExpectations(t *testing.T) {
+	ctrl := gomock.NewController(t, gomock.WithOverridableExpectations())
+	mockIndex := NewMockFoo(ctrl)
+
+	mockIndex.EXPECT().Bar(gomock.Any()).Return("foo")
+	ctrl.PushScope()
+	mockIndex.EXPECT().Bar(gomock.Any()).Return("bar")
+	if res := mockIndex.Bar("input"); res != "bar" {
+		t.Fatalf("expected response to equal 'bar', got %s", res)
+	}
+	ctrl.PopScope()
+
+	if res := mockIndex.Bar("input"); res != "foo" {
+		t.Fatalf("expected response to equal 'foo', got %s", res)
+	}
+}
+
+func TestPopScope_MissingCalls(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	mockIndex := NewMockFoo(ctrl)
+
+	ctrl.PushScope()
+	mockIndex.EXPECT().Bar("input").Return("bar")
+	ctrl.PopScope()
+	reporter.assertFail("Missing call at the end of the scope.")
+
+	// The expectations of the scope are removed.
+	reporter.failed = false
+	ctrl.Finish()
+	reporter.assertPass("Missing call reported at the end of the scope.")
+}
+
+func TestPopScope_NoScope(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+
+	reporter.assertFatal(func() {
+		ctrl.PopScope()
+	}, "without a matching PushScope")
+}
+
+func TestPopScope_AfterCheckpoint(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	mockIndex := NewMockFoo(ctrl)
+
+	mockIndex.EXPECT().Bar("a").Return("foo")
+	ctrl.PushScope()
+	mockIndex.EXPECT().Bar("a").Return("bar").Override()
+	mockIndex.EXPECT().Bar("b").Return("baz")
+	if res := mockIndex.Bar("a"); res != "bar" {
+		t.Fatalf("expected response to equal 'bar', got %s", res)
+	}
+	ctrl.Checkpoint()
+	reporter.assertFail("Missing call at the checkpoint.")
+
+	// The calls cleared by the checkpoint are neither reported again nor
+	// restored at the end of the scope.
+	reporter.failed = false
+	ctrl.PopScope()
+	reporter.assertPass("Missing call reported at the checkpoint.")
+	ctrl.Finish()
+	reporter.assertPass("Overridden call cleared by the checkpoint.")
+}
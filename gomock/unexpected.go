@@ -0,0 +1,97 @@
+package gomock
+
+import (
+	"reflect"
+)
+
+// An UnexpectedCall is a call of a mock matching no expected call, which is
+// passed to the handler registered with [Controller.OnUnexpected].
+type UnexpectedCall struct {
+	Receiver any
+	Method   string
+	Args     []any
+	// Err explains why the call matches no expected call.
+	Err error
+}
+
+type onUnexpectedOption func(UnexpectedCall) []any
+
+// WithOnUnexpected registers f as the handler of the unexpected calls of the
+// mocks of the Controller. See [Controller.OnUnexpected].
+func WithOnUnexpected(f func(UnexpectedCall) []any) ControllerOption {
+	return onUnexpectedOption(f)
+}
+
+func (o onUnexpectedOption) apply(ctrl *Controller) {
+	ctrl.onUnexpected = o
+}
+
+// OnUnexpected registers f as the handler of the calls of the mocks of ctrl
+// that match no expected call. Instead of failing the test, such calls
+// return the results of f, or the default values of their results (see
+// [SetDefaultReturn]) if f returns nil:
+//
+//	ctrl.OnUnexpected(func(call gomock.UnexpectedCall) []any {
+//	  t.Logf("ignoring %v", call.Err)
+//	  return nil
+//	})
+//
+// f can still fail the test, for instance for some methods only. The handler
+// of a sub-controller (see [Controller.Sub]) takes precedence over the one
+// of its parent. Passing nil removes the handler.
+func (ctrl *Controller) OnUnexpected(f func(UnexpectedCall) []any) {
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+	ctrl.onUnexpected = f
+}
+
+// unexpectedHandler returns the handler of unexpected calls of the innermost
+// sub-controller of ctrl or of its parents, if any.
+func (ctrl *Controller) unexpectedHandler() func(UnexpectedCall) []any {
+	for c := ctrl.innermost(); c != nil; c = c.parent {
+		c.mu.RLock()
+		f := c.onUnexpected
+		c.mu.RUnlock()
+		if f != nil {
+			return f
+		}
+	}
+	return nil
+}
+
+// handleUnexpected returns the results of the unexpected call of method of
+// receiver with args, given by the handler f.
+func (ctrl *Controller) handleUnexpected(f func(UnexpectedCall) []any, receiver any, method string, args []any, err error) []any {
+	ctrl.T.Helper()
+
+	rets := f(UnexpectedCall{Receiver: receiver, Method: method, Args: args, Err: err})
+	if rets == nil {
+		return ctrl.defaultResults(receiver, method)
+	}
+	m := reflect.ValueOf(receiver).MethodByName(method)
+	if !m.IsValid() {
+		return rets
+	}
+	methodType := m.Type()
+	if len(rets) != methodType.NumOut() {
+		ctrl.innermost().T.Fatalf("wrong number of results returned by the OnUnexpected handler for %T.%v: got %d, want %d",
+			receiver, method, len(rets), methodType.NumOut())
+	}
+	return rets
+}
+
+// defaultResults returns the default values of the results of method of
+// receiver, or nil if receiver has no such method.
+func (ctrl *Controller) defaultResults(receiver any, method string) []any {
+	m := reflect.ValueOf(receiver).MethodByName(method)
+	if !m.IsValid() {
+		return nil
+	}
+	methodType := m.Type()
+	target := ctrl.innermost()
+	rets := make([]any, methodType.NumOut())
+	for i := range rets {
+		rets[i] = target.defaultReturn(receiver, methodType.Out(i))
+	}
+	return rets
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strings"
+)
+
+var cacheDir = flag.String("cache_dir", "", "(archive and package mode) Directory in which to cache generated mocks. When the package export data, the interface list and the flags are unchanged, the cached output is used without parsing or generating anything.")
+
+// cacheFormat is part of every cache key. Bump it whenever the generated
+// output changes for the same input so that stale entries are not reused.
+const cacheFormat = "mockgen-cache-v1"
+
+// outputCache is an on-disk cache of generated mocks keyed by the hash of
+// everything the output depends on.
+// A nil *outputCache is valid and never hits.
+type outputCache struct {
+	dir string
+	key string // set by lookup
+}
+
+func newOutputCache(dir string) *outputCache {
+	if dir == "" {
+		return nil
+	}
+	return &outputCache{dir: dir}
+}
+
+// lookup computes the cache key for the given export data file, the
+// current command line and the other input files and returns the cached
+// output, if any.
+// The key is remembered so that a subsequent call to store can fill the entry.
+func (c *outputCache) lookup(exportFile string, deps map[string]string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	key, err := cacheKey(exportFile, commandArgs(), cacheInputs(deps))
+	if err != nil {
+		log.Printf("Unable to compute cache key: %v", err)
+		return nil, false
+	}
+	c.key = key
+
+	output, err := os.ReadFile(c.path())
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Printf("Unable to read cache entry: %v", err)
+		}
+		return nil, false
+	}
+	return output, true
+}
+
+// store records output under the key computed by the last lookup.
+// Failures are logged but otherwise ignored, the cache is best-effort.
+func (c *outputCache) store(output []byte) {
+	if c == nil || c.key == "" {
+		return
+	}
+	if err := c.write(output); err != nil {
+		log.Printf("Unable to write cache entry: %v", err)
+	}
+}
+
+func (c *outputCache) write(output []byte) error {
+	if err := os.MkdirAll(c.dir, os.ModePerm); err != nil {
+		return err
+	}
+	// Write to a temporary file first so that concurrent mockgen
+	// invocations never observe a partially written entry.
+	f, err := os.CreateTemp(c.dir, c.key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(output); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), c.path())
+}
+
+func (c *outputCache) path() string {
+	return filepath.Join(c.dir, c.key)
+}
+
+// cacheInputs returns the files other than the export data that the
+// output depends on: the copyright header, the template, the importcfg and
+// the export data of the archive dependencies, in a stable order.
+func cacheInputs(deps map[string]string) []string {
+	var files []string
+	for _, f := range []string{*copyrightFile, *templateFile, *importcfg} {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	pkgPaths := make([]string, 0, len(deps))
+	for pkgPath := range deps {
+		pkgPaths = append(pkgPaths, pkgPath)
+	}
+	sort.Strings(pkgPaths)
+	for _, pkgPath := range pkgPaths {
+		files = append(files, deps[pkgPath])
+	}
+	return files
+}
+
+// cacheKey returns the hex encoded hash of the export data in exportFile,
+// the command line arguments (which hold the flags and the interface list),
+// the working directory the relative paths among them are resolved against,
+// the contents of the other input files and the version of mockgen itself.
+func cacheKey(exportFile string, args, inputs []string) (string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n", cacheFormat, mockgenVersion())
+	fmt.Fprintf(h, "%q\n%q\n", wd, args)
+
+	for _, file := range append([]string{exportFile}, inputs...) {
+		if err := hashFile(h, file); err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashFile writes the length and contents of file to h. The length keeps
+// the boundaries between consecutive files unambiguous.
+func hashFile(h io.Writer, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(h, "%d\n", fi.Size())
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Errorf("hash %q: %v", file, err)
+	}
+	return nil
+}
+
+// mockgenVersion identifies the running mockgen binary as precisely as
+// possible, so that upgrading mockgen invalidates the cache.
+func mockgenVersion() string {
+	parts := []string{version, commit, date}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		parts = append(parts, bi.Main.Version)
+		for _, s := range bi.Settings {
+			if s.Key == "vcs.revision" || s.Key == "vcs.modified" {
+				parts = append(parts, s.Value)
+			}
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheKey(t *testing.T) {
+	dir := t.TempDir()
+	exportFile := filepath.Join(dir, "pkg.a")
+	require.NoError(t, os.WriteFile(exportFile, []byte("export data"), 0o644))
+
+	key, err := cacheKey(exportFile, []string{"-typed", "example.com/foo", "Foo"}, nil)
+	require.NoError(t, err)
+
+	same, err := cacheKey(exportFile, []string{"-typed", "example.com/foo", "Foo"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, key, same)
+
+	otherIfaces, err := cacheKey(exportFile, []string{"-typed", "example.com/foo", "Foo,Bar"}, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, otherIfaces)
+
+	otherFlags, err := cacheKey(exportFile, []string{"example.com/foo", "Foo"}, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, otherFlags)
+
+	require.NoError(t, os.WriteFile(exportFile, []byte("changed export data"), 0o644))
+	otherData, err := cacheKey(exportFile, []string{"-typed", "example.com/foo", "Foo"}, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, otherData)
+
+	_, err = cacheKey(filepath.Join(dir, "missing.a"), nil, nil)
+	assert.Error(t, err)
+}
+
+func TestCacheKeyInputs(t *testing.T) {
+	dir := t.TempDir()
+	exportFile := filepath.Join(dir, "pkg.a")
+	depFile := filepath.Join(dir, "dep.a")
+	tmplFile := filepath.Join(dir, "mock.tmpl")
+	require.NoError(t, os.WriteFile(exportFile, []byte("export data"), 0o644))
+	require.NoError(t, os.WriteFile(depFile, []byte("dep export data"), 0o644))
+	require.NoError(t, os.WriteFile(tmplFile, []byte("template"), 0o644))
+
+	args := []string{"-template=mock.tmpl", "-archive_dep=example.com/dep=dep.a", "example.com/foo", "Foo"}
+	inputs := []string{tmplFile, depFile}
+	key, err := cacheKey(exportFile, args, inputs)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(depFile, []byte("changed dep export data"), 0o644))
+	otherDep, err := cacheKey(exportFile, args, inputs)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, otherDep, "changed dependency export data")
+
+	require.NoError(t, os.WriteFile(tmplFile, []byte("changed template"), 0o644))
+	otherTmpl, err := cacheKey(exportFile, args, inputs)
+	require.NoError(t, err)
+	assert.NotEqual(t, otherDep, otherTmpl, "changed template")
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { os.Chdir(wd) })
+	otherWd, err := cacheKey(exportFile, args, inputs)
+	require.NoError(t, err)
+	assert.NotEqual(t, otherTmpl, otherWd, "changed working directory")
+
+	_, err = cacheKey(exportFile, args, []string{filepath.Join(dir, "missing.tmpl")})
+	assert.Error(t, err)
+}
+
+func TestCacheInputs(t *testing.T) {
+	defer func(tmpl, cfg string) { *templateFile, *importcfg = tmpl, cfg }(*templateFile, *importcfg)
+	*templateFile, *importcfg = "mock.tmpl", "importcfg"
+
+	got := cacheInputs(map[string]string{"example.com/b": "b.a", "example.com/a": "a.a"})
+	assert.Equal(t, []string{"mock.tmpl", "importcfg", "a.a", "b.a"}, got)
+}
+
+func TestOutputCache(t *testing.T) {
+	exportFile := filepath.Join(t.TempDir(), "pkg.a")
+	require.NoError(t, os.WriteFile(exportFile, []byte("export data"), 0o644))
+
+	t.Run("nil cache never hits", func(t *testing.T) {
+		var c *outputCache
+		_, ok := c.lookup(exportFile, nil)
+		assert.False(t, ok)
+		c.store([]byte("output")) // must not panic
+	})
+
+	t.Run("stored output is returned", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "cache")
+		c := newOutputCache(dir)
+		_, ok := c.lookup(exportFile, nil)
+		require.False(t, ok)
+		c.store([]byte("output"))
+
+		c = newOutputCache(dir)
+		got, ok := c.lookup(exportFile, nil)
+		require.True(t, ok)
+		assert.Equal(t, "output", string(got))
+	})
+
+	t.Run("changed export data misses", func(t *testing.T) {
+		dir := t.TempDir()
+		c := newOutputCache(dir)
+		c.lookup(exportFile, nil)
+		c.store([]byte("output"))
+
+		changed := filepath.Join(t.TempDir(), "pkg.a")
+		require.NoError(t, os.WriteFile(changed, []byte("other export data"), 0o644))
+		_, ok := newOutputCache(dir).lookup(changed, nil)
+		assert.False(t, ok)
+	})
+}
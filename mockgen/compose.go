@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/mock/mockgen/generate"
+)
+
+// parseCompositions parses the value of the -compose flag.
+func parseCompositions(spec string) ([]generate.Composition, error) {
+	var comps []generate.Composition
+	for _, s := range strings.Split(spec, ";") {
+		if s == "" {
+			continue
+		}
+		name, list, ok := strings.Cut(s, "=")
+		if !ok || name == "" || list == "" {
+			return nil, fmt.Errorf("bad compose spec %q, want Name=iface1,iface2", s)
+		}
+		comps = append(comps, generate.Composition{Name: name, Interfaces: strings.Split(list, ",")})
+	}
+	return comps, nil
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/mockgen/generate"
+)
+
+func TestParseCompositions(t *testing.T) {
+	comps, err := parseCompositions("ReadCloserStore=io.Reader,io.Closer,example.com/x.Store;Empty=io.Closer")
+	require.NoError(t, err)
+	assert.Equal(t, []generate.Composition{
+		{
+			Name:       "ReadCloserStore",
+			Interfaces: []string{"io.Reader", "io.Closer", "example.com/x.Store"},
+		},
+		{
+			Name:       "Empty",
+			Interfaces: []string{"io.Closer"},
+		},
+	}, comps)
+
+	for _, spec := range []string{"Foo", "=io.Reader", "Foo="} {
+		_, err := parseCompositions(spec)
+		assert.Error(t, err, spec)
+	}
+}
@@ -0,0 +1,105 @@
+package generate
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"os"
+
+	"go.uber.org/mock/mockgen/model"
+
+	"golang.org/x/tools/go/gcexportdata"
+)
+
+func parseExportFile(importPath string, symbols []string, archive string, opts LoadOptions) (*model.Package, error) {
+	r := newExportDataReader(opts.ArchiveDeps)
+	tp, err := r.read(importPath, archive)
+	if err != nil {
+		return nil, err
+	}
+	return r.extract(tp, symbols, opts)
+}
+
+// extract extracts the interfaces symbols of tp, read by r.
+func (r *exportDataReader) extract(tp *types.Package, symbols []string, opts LoadOptions) (*model.Package, error) {
+	interfaces, err := extractInterfacesFromPackageTypes(r.fset, tp, symbols, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &model.Package{
+		Name:       tp.Name(),
+		PkgPath:    tp.Path(),
+		Interfaces: interfaces,
+	}
+	return pkg, nil
+}
+
+// An exportDataReader reads export data files into a shared map of packages,
+// so that the packages imported by several of them are represented once.
+//
+// The export data of a package describes the objects of its dependencies it
+// refers to, so the export data of a dependency is only read when objects
+// of the dependency that are missing are needed, such as the interfaces
+// composed with -compose.
+type exportDataReader struct {
+	fset    *token.FileSet
+	imports map[string]*types.Package
+	deps    map[string]string // import path => export data file; may be nil
+	done    map[string]bool   // the packages read from their own export data
+}
+
+func newExportDataReader(deps map[string]string) *exportDataReader {
+	return &exportDataReader{
+		fset:    token.NewFileSet(),
+		imports: make(map[string]*types.Package),
+		deps:    deps,
+		done:    make(map[string]bool),
+	}
+}
+
+// read reads the package importPath from the export data in file.
+func (r *exportDataReader) read(importPath, file string) (*types.Package, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	er, err := gcexportdata.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("read export data %q: %v", file, err)
+	}
+	tp, err := gcexportdata.Read(er, r.fset, r.imports, importPath)
+	if err != nil {
+		return nil, err
+	}
+	r.done[importPath] = true
+	return tp, nil
+}
+
+// parsePackage extracts the interfaces symbols of the dependency importPath.
+// Its export data is read from r.deps unless all of symbols are already known.
+func (r *exportDataReader) parsePackage(importPath string, symbols []string, opts LoadOptions) (*model.Package, error) {
+	tp := r.imports[importPath]
+	if tp == nil || (!r.done[importPath] && !hasObjects(tp, symbols)) {
+		file, ok := r.deps[importPath]
+		if !ok {
+			return nil, fmt.Errorf("no export data for dependency %s", importPath)
+		}
+		var err error
+		if tp, err = r.read(importPath, file); err != nil {
+			return nil, fmt.Errorf("read dependency %s: %w", importPath, err)
+		}
+	}
+	return r.extract(tp, symbols, opts)
+}
+
+// hasObjects reports whether all of names are declared in the scope of pkg.
+func hasObjects(pkg *types.Package, names []string) bool {
+	for _, name := range names {
+		if pkg.Scope().Lookup(name) == nil {
+			return false
+		}
+	}
+	return true
+}
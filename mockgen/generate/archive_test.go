@@ -0,0 +1,67 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+// exportFiles returns the export data files of the packages patterns and
+// of their dependencies.
+func exportFiles(t *testing.T, patterns ...string) map[string]string {
+	t.Helper()
+	cfg := &packages.Config{Mode: packages.NeedName | packages.NeedExportFile | packages.NeedImports | packages.NeedDeps}
+	pkgs, err := packages.Load(cfg, patterns...)
+	require.NoError(t, err)
+	files := make(map[string]string)
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if pkg.ExportFile != "" {
+			files[pkg.PkgPath] = pkg.ExportFile
+		}
+	})
+	return files
+}
+
+func TestLoadArchiveDeps(t *testing.T) {
+	const pkgPath = "go.uber.org/mock/mockgen/internal/tests/package_mode"
+	files := exportFiles(t, pkgPath, "io")
+	archive := files[pkgPath]
+	require.NotEmpty(t, archive)
+	want, err := LoadArchive(archive, pkgPath, []string{"Car", "Animal"}, LoadOptions{})
+	require.NoError(t, err)
+
+	// Without the go command, the dependencies and composed interfaces are
+	// read from their export data.
+	t.Setenv("PATH", "")
+	opts := LoadOptions{ArchiveDeps: files}
+	got, err := LoadArchive(archive, pkgPath, []string{"Car", "Animal"}, opts)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	intfs, err := Compose([]Composition{{Name: "ReadCloser", Interfaces: []string{"io.Reader", "io.Closer"}}}, opts)
+	require.NoError(t, err)
+	require.Len(t, intfs, 1)
+	var names []string
+	for _, m := range intfs[0].Methods {
+		names = append(names, m.Name)
+	}
+	assert.ElementsMatch(t, []string{"Read", "Close"}, names)
+
+	_, err = Compose([]Composition{{Name: "Writer", Interfaces: []string{"io.Writer"}}}, LoadOptions{})
+	assert.Error(t, err, "package io is loaded with the go command")
+
+	// The export data of a dependency is only opened when it is needed, so
+	// the entries unrelated to the archive may even be missing.
+	opts.ArchiveDeps = map[string]string{
+		"go.uber.org/mock/mockgen/internal/tests/package_mode/fuel": archive + ".missing",
+		"example.com/unrelated": archive + ".missing",
+	}
+	got, err = LoadArchive(archive, pkgPath, []string{"Car", "Animal"}, opts)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	_, err = Compose([]Composition{{Name: "Store", Interfaces: []string{"example.com/unrelated.Store"}}}, opts)
+	assert.ErrorContains(t, err, "read dependency example.com/unrelated")
+}
@@ -0,0 +1,149 @@
+package generate
+
+// This file contains the generation of the builders of expected calls with
+// named arguments, such as EXPECT().FindWith().UserID(x).Limit(10).Call().
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go.uber.org/mock/mockgen/model"
+)
+
+// hasArgBuilder reports whether a builder is generated for the method m of
+// intf: m has at least g.argBuilders parameters, and the builder's recorder
+// method, m.Name + "With", does not clash with another method of intf.
+func (g *generator) hasArgBuilder(intf *model.Interface, m *model.Method) bool {
+	if g.argBuilders <= 0 {
+		return false
+	}
+	n := len(m.In)
+	if m.Variadic != nil {
+		n++
+	}
+	if n < g.argBuilders {
+		return false
+	}
+	for _, other := range intf.Methods {
+		if other.Name == m.Name+"With" {
+			return false
+		}
+	}
+	return true
+}
+
+// argSetterNames returns the names of the setters of the arguments of m:
+// the exported names of its parameters, or Arg<i> for unnamed parameters.
+func argSetterNames(m *model.Method) []string {
+	params := m.In
+	if m.Variadic != nil {
+		params = append(params[:len(params):len(params)], m.Variadic)
+	}
+	taken := map[string]bool{"Call": true}
+	names := make([]string, len(params))
+	for i, p := range params {
+		name := p.Name
+		if name == "" || name == "_" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		r, size := utf8.DecodeRuneInString(name)
+		name = string(unicode.ToUpper(r)) + name[size:]
+		for taken[name] {
+			name += "Arg"
+		}
+		taken[name] = true
+		names[i] = name
+	}
+	return names
+}
+
+// GenerateArgBuilder generates the builder of expected calls of m, whose
+// arguments are set by name and match anything by default, and the recorder
+// method returning it.
+func (g *generator) GenerateArgBuilder(intf *model.Interface, m *model.Method, longTp, shortTp string, typed bool) {
+	mockType := g.mockName(intf.Name)
+	builderType := mockType + m.Name + "Builder"
+	argNames := g.getArgNames(m, true /* in */)
+	setterNames := argSetterNames(m)
+	nArgs := len(m.In)
+
+	anys := make([]string, nArgs)
+	for i := range anys {
+		anys[i] = "gomock.Any()"
+	}
+	init := fmt.Sprintf("mock: mr.mock, args: []any{%s}", strings.Join(anys, ", "))
+	if m.Variadic != nil {
+		init += ", varargs: []any{gomock.Any()}"
+	}
+
+	g.p("// %vWith returns a builder of an expected call of %v whose arguments are", m.Name, m.Name)
+	g.p("// set by name. Unset arguments match anything.")
+	g.p("func (mr *%vMockRecorder%v) %vWith() *%v%v {", mockType, shortTp, m.Name, builderType, shortTp)
+	g.in()
+	g.p("return &%v%v{%v}", builderType, shortTp, init)
+	g.out()
+	g.p("}")
+	g.p("")
+
+	g.p("// %v builds an expected call of %v.", builderType, m.Name)
+	g.p("type %v%v struct {", builderType, longTp)
+	g.in()
+	g.p("mock *%v%v", mockType, shortTp)
+	g.p("args []any")
+	if m.Variadic != nil {
+		g.p("varargs []any")
+	}
+	g.out()
+	g.p("}")
+
+	ia := newIdentifierAllocator(argNames)
+	idRecv := ia.allocateIdentifier("b")
+	for i := 0; i < nArgs; i++ {
+		g.p("")
+		g.p("// %v sets the matcher of the %v argument.", setterNames[i], argNames[i])
+		g.p("func (%v *%v%v) %v(%v any) *%v%v {", idRecv, builderType, shortTp, setterNames[i], argNames[i], builderType, shortTp)
+		g.in()
+		g.p("%v.args[%d] = %v", idRecv, i, argNames[i])
+		g.p("return %v", idRecv)
+		g.out()
+		g.p("}")
+	}
+	if m.Variadic != nil {
+		name := argNames[nArgs]
+		g.p("")
+		g.p("// %v sets the matchers of the variadic %v arguments.", setterNames[nArgs], name)
+		g.p("func (%v *%v%v) %v(%v ...any) *%v%v {", idRecv, builderType, shortTp, setterNames[nArgs], name, builderType, shortTp)
+		g.in()
+		g.p("%v.varargs = %v", idRecv, name)
+		g.p("return %v", idRecv)
+		g.out()
+		g.p("}")
+	}
+
+	callArgs := idRecv + ".args..."
+	if m.Variadic != nil {
+		callArgs = fmt.Sprintf("append(%v.args, %v.varargs...)...", idRecv, idRecv)
+	}
+	record := fmt.Sprintf(`%v.mock.ctrl.RecordCallWithMethodType(%v.mock, "%v", reflect.TypeOf((*%v%v)(nil).%v), %v)`,
+		idRecv, idRecv, m.Name, mockType, shortTp, m.Name, callArgs)
+
+	g.p("")
+	g.p("// Call records the expected call of %v.", m.Name)
+	if typed {
+		g.p("func (%v *%v%v) Call() *%v%vCall%v {", idRecv, builderType, shortTp, mockType, m.Name, shortTp)
+	} else {
+		g.p("func (%v *%v%v) Call() *gomock.Call {", idRecv, builderType, shortTp)
+	}
+	g.in()
+	g.p("%v.mock.ctrl.T.Helper()", idRecv)
+	if typed {
+		g.p("call := %v", record)
+		g.p("return &%v%vCall%v{Call: call}", mockType, m.Name, shortTp)
+	} else {
+		g.p("return %v", record)
+	}
+	g.out()
+	g.p("}")
+}
@@ -0,0 +1,99 @@
+package generate
+
+// This file contains the support for copying doc comments.
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+
+	"go.uber.org/mock/mockgen/model"
+)
+
+// loadDocComments fills in the missing doc comments of the interfaces and
+// methods of pkg by parsing the source files at their recorded positions.
+// This is needed in archive and package mode, as export data has no comments.
+// Doc comments are best-effort: files that cannot be parsed are skipped.
+func loadDocComments(pkg *model.Package) {
+	docs := &docIndex{
+		fset:  token.NewFileSet(),
+		files: make(map[string]map[int]string),
+	}
+	for _, intf := range pkg.Interfaces {
+		if intf.Doc == "" {
+			intf.Doc = docs.lookup(intf.Pos)
+		}
+		for _, m := range intf.Methods {
+			if m.Doc == "" {
+				m.Doc = docs.lookup(m.Pos)
+			}
+		}
+	}
+}
+
+// docIndex maps the positions of type names and interface method names to
+// their doc comments.
+type docIndex struct {
+	fset  *token.FileSet
+	files map[string]map[int]string // file name -> line -> doc comment
+}
+
+func (d *docIndex) lookup(pos token.Position) string {
+	if !pos.IsValid() || pos.Filename == "" {
+		return ""
+	}
+	lines, ok := d.files[pos.Filename]
+	if !ok {
+		lines = d.index(pos.Filename)
+		d.files[pos.Filename] = lines
+	}
+	return lines[pos.Line]
+}
+
+func (d *docIndex) index(filename string) map[int]string {
+	file, err := parser.ParseFile(d.fset, filename, nil, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil
+	}
+
+	lines := make(map[int]string)
+	add := func(name *ast.Ident, doc *ast.CommentGroup) {
+		if doc != nil {
+			lines[d.fset.Position(name.Pos()).Line] = doc.Text()
+		}
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.GenDecl:
+			for _, spec := range n.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok {
+					add(ts.Name, typeSpecDoc(n, ts))
+				}
+			}
+		case *ast.InterfaceType:
+			for _, field := range n.Methods.List {
+				if len(field.Names) == 1 {
+					add(field.Names[0], field.Doc)
+				}
+			}
+		}
+		return true
+	})
+	return lines
+}
+
+// docComment writes doc as a paragraph appended to the comment just written.
+func (g *generator) docComment(doc string) {
+	if !g.docComments || doc == "" {
+		return
+	}
+	g.p("//")
+	for _, line := range strings.Split(strings.TrimRight(doc, "\n"), "\n") {
+		if line == "" {
+			g.p("//")
+		} else {
+			g.p("// %s", line)
+		}
+	}
+}
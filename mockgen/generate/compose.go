@@ -0,0 +1,145 @@
+package generate
+
+// This file contains the support for composite mocks.
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/mock/mockgen/model"
+)
+
+// A Composition requests a single mock implementing all of its interfaces.
+type Composition struct {
+	// Name is the name of the composite interface; its mock is named after it.
+	Name string
+	// Interfaces are the qualified names of the composed interfaces, such as
+	// io.Reader or example.com/x.Store.
+	Interfaces []string
+}
+
+// Compose builds the composite interfaces requested by comps. The packages
+// of the composed interfaces are loaded from their export data in
+// opts.ArchiveDeps, or else as in package mode.
+func Compose(comps []Composition, opts LoadOptions) ([]*model.Interface, error) {
+	parser := packageModeParser{opts: opts}
+	r := newExportDataReader(opts.ArchiveDeps)
+	return composeInterfaces(comps, func(pkgPath string, ifaces []string) (*model.Package, error) {
+		if _, ok := opts.ArchiveDeps[pkgPath]; ok {
+			return r.parsePackage(pkgPath, ifaces, opts)
+		}
+		return parser.parsePackage(pkgPath, ifaces)
+	})
+}
+
+type qualifiedName struct {
+	pkgPath, name string
+}
+
+func (q qualifiedName) String() string {
+	return q.pkgPath + "." + q.name
+}
+
+// parseQualifiedName splits a name such as example.com/x.Store into its
+// import path and its identifier.
+func parseQualifiedName(s string) (qualifiedName, error) {
+	i := strings.LastIndex(s, ".")
+	if i <= 0 || i < strings.LastIndex(s, "/") || i == len(s)-1 {
+		return qualifiedName{}, fmt.Errorf("bad interface name %q, want a qualified name such as io.Reader", s)
+	}
+	return qualifiedName{pkgPath: s[:i], name: s[i+1:]}, nil
+}
+
+// composeInterfaces builds the composite interfaces requested by comps.
+// Component interfaces are loaded with load, once per package.
+func composeInterfaces(comps []Composition, load func(pkgPath string, ifaces []string) (*model.Package, error)) ([]*model.Interface, error) {
+	// Gather the interfaces needed from every package, so that each
+	// package is loaded only once.
+	components := make([][]qualifiedName, len(comps))
+	var pkgPaths []string
+	wanted := make(map[string][]string)
+	for i, c := range comps {
+		for _, intf := range c.Interfaces {
+			qn, err := parseQualifiedName(intf)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", c.Name, err)
+			}
+			components[i] = append(components[i], qn)
+			if _, ok := wanted[qn.pkgPath]; !ok {
+				pkgPaths = append(pkgPaths, qn.pkgPath)
+			}
+			wanted[qn.pkgPath] = append(wanted[qn.pkgPath], qn.name)
+		}
+	}
+
+	loaded := make(map[qualifiedName]*model.Interface)
+	for _, pkgPath := range pkgPaths {
+		pkg, err := load(pkgPath, wanted[pkgPath])
+		if err != nil {
+			return nil, fmt.Errorf("load %s: %w", pkgPath, err)
+		}
+		for _, intf := range pkg.Interfaces {
+			loaded[qualifiedName{pkgPath: pkgPath, name: intf.Name}] = intf
+		}
+	}
+
+	intfs := make([]*model.Interface, len(comps))
+	for i, c := range comps {
+		intf, err := compose(c.Name, components[i], loaded)
+		if err != nil {
+			return nil, err
+		}
+		intfs[i] = intf
+	}
+	return intfs, nil
+}
+
+func compose(name string, components []qualifiedName, loaded map[qualifiedName]*model.Interface) (*model.Interface, error) {
+	composed := &model.Interface{Name: name}
+	origin := make(map[string]qualifiedName) // method name -> component declaring it
+	methods := make(map[string]*model.Method)
+	for _, qn := range components {
+		component, ok := loaded[qn]
+		if !ok {
+			return nil, fmt.Errorf("%s: interface %s does not exist", name, qn)
+		}
+		if len(component.TypeParams) > 0 {
+			return nil, fmt.Errorf("%s: generic interface %s cannot be composed", name, qn)
+		}
+		for _, m := range component.Methods {
+			if prev, ok := methods[m.Name]; ok {
+				if a, b := signature(prev), signature(m); a != b {
+					return nil, fmt.Errorf("%s: method %s of %s conflicts with %s: %s != %s", name, m.Name, qn, origin[m.Name], b, a)
+				}
+				continue
+			}
+			origin[m.Name] = qn
+			methods[m.Name] = m
+			composed.AddMethod(m)
+		}
+	}
+	return composed, nil
+}
+
+// signature returns the signature of m with fully qualified type names.
+// Parameter names are not part of the signature.
+func signature(m *model.Method) string {
+	pkg := &model.Package{Interfaces: []*model.Interface{{Methods: []*model.Method{m}}}}
+	pm := make(map[string]string)
+	for pkgPath := range pkg.Imports() {
+		pm[pkgPath] = pkgPath
+	}
+
+	types := func(params []*model.Parameter) []string {
+		s := make([]string, len(params))
+		for i, p := range params {
+			s[i] = p.Type.String(pm, "")
+		}
+		return s
+	}
+	in := types(m.In)
+	if m.Variadic != nil {
+		in = append(in, "..."+m.Variadic.Type.String(pm, ""))
+	}
+	return fmt.Sprintf("func(%s) (%s)", strings.Join(in, ", "), strings.Join(types(m.Out), ", "))
+}
@@ -0,0 +1,100 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/mockgen/model"
+)
+
+func TestComposeInterfaces(t *testing.T) {
+	errorType := model.PredeclaredType("error")
+	closeMethod := &model.Method{Name: "Close", Out: []*model.Parameter{{Type: errorType}}}
+	packages := map[string]*model.Package{
+		"io": {
+			Interfaces: []*model.Interface{
+				{
+					Name: "Reader",
+					Methods: []*model.Method{{
+						Name: "Read",
+						In:   []*model.Parameter{{Name: "p", Type: &model.ArrayType{Len: -1, Type: model.PredeclaredType("byte")}}},
+						Out:  []*model.Parameter{{Type: model.PredeclaredType("int")}, {Type: errorType}},
+					}},
+				},
+				{Name: "Closer", Methods: []*model.Method{closeMethod}},
+			},
+		},
+		"example.com/x": {
+			Interfaces: []*model.Interface{
+				{
+					Name: "Store",
+					Methods: []*model.Method{
+						{Name: "Close", Out: []*model.Parameter{{Name: "err", Type: errorType}}},
+						{Name: "Get", In: []*model.Parameter{{Type: model.PredeclaredType("string")}}},
+					},
+				},
+				{
+					Name:    "Conn",
+					Methods: []*model.Method{{Name: "Close"}},
+				},
+				{
+					Name:       "Set",
+					TypeParams: []*model.Parameter{{Name: "T", Type: &model.NamedType{Type: "any"}}},
+				},
+			},
+		},
+	}
+	var loads []string
+	load := func(pkgPath string, ifaces []string) (*model.Package, error) {
+		loads = append(loads, pkgPath)
+		return packages[pkgPath], nil
+	}
+
+	t.Run("methods are merged", func(t *testing.T) {
+		loads = nil
+		comps := []Composition{{Name: "ReadCloserStore", Interfaces: []string{"io.Reader", "io.Closer", "example.com/x.Store"}}}
+
+		intfs, err := composeInterfaces(comps, load)
+		require.NoError(t, err)
+		require.Len(t, intfs, 1)
+		assert.Equal(t, "ReadCloserStore", intfs[0].Name)
+		var names []string
+		for _, m := range intfs[0].Methods {
+			names = append(names, m.Name)
+		}
+		assert.Equal(t, []string{"Read", "Close", "Get"}, names)
+		assert.Same(t, closeMethod, intfs[0].Methods[1])
+		assert.Equal(t, []string{"io", "example.com/x"}, loads)
+	})
+
+	t.Run("conflicting signatures", func(t *testing.T) {
+		comps := []Composition{{Name: "CloserConn", Interfaces: []string{"io.Closer", "example.com/x.Conn"}}}
+
+		_, err := composeInterfaces(comps, load)
+		assert.EqualError(t, err, "CloserConn: method Close of example.com/x.Conn conflicts with io.Closer: func() () != func() (error)")
+	})
+
+	t.Run("missing interface", func(t *testing.T) {
+		comps := []Composition{{Name: "Missing", Interfaces: []string{"io.Writer"}}}
+
+		_, err := composeInterfaces(comps, load)
+		assert.EqualError(t, err, "Missing: interface io.Writer does not exist")
+	})
+
+	t.Run("generic interface", func(t *testing.T) {
+		comps := []Composition{{Name: "Generic", Interfaces: []string{"example.com/x.Set"}}}
+
+		_, err := composeInterfaces(comps, load)
+		assert.EqualError(t, err, "Generic: generic interface example.com/x.Set cannot be composed")
+	})
+	t.Run("bad interface names", func(t *testing.T) {
+		for _, name := range []string{"Reader", "example.com/x", "io."} {
+			comps := []Composition{{Name: "Bad", Interfaces: []string{name}}}
+
+			_, err := composeInterfaces(comps, load)
+			assert.Error(t, err, name)
+		}
+	})
+}
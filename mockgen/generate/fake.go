@@ -0,0 +1,261 @@
+package generate
+
+// This file contains the generation of counterfeiter-style fakes.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go.uber.org/mock/mockgen/model"
+)
+
+// The name of the fake type to use for the given interface identifier.
+func (g *generator) fakeName(typeName string) string {
+	if fakeName, ok := g.mockNames[typeName]; ok {
+		return fakeName
+	}
+
+	return "Fake" + typeName
+}
+
+// GenerateFakeInterface generates a fake implementation of intf that records
+// its calls and returns configurable values instead of checking expectations.
+func (g *generator) GenerateFakeInterface(intf *model.Interface, outputPackagePath string) error {
+	fakeType := g.fakeName(intf.Name)
+	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
+
+	sort.Sort(byMethodName(intf.Methods))
+
+	if intf.Extracted {
+		g.GenerateExtractedInterface(intf, outputPackagePath)
+	}
+
+	g.p("")
+	switch {
+	case intf.FuncType:
+		g.p("// %v is a fake implementation of %v function type.", fakeType, g.mockedName(intf, outputPackagePath))
+	case intf.Extracted:
+		g.p("// %v is a fake implementation of %v.", fakeType, extractedInterfaceName(intf.Name))
+	default:
+		g.p("// %v is a fake implementation of %v interface.", fakeType, g.mockedName(intf, outputPackagePath))
+	}
+	g.docComment(intf.Doc)
+	g.p("type %v%v struct {", fakeType, longTp)
+	g.in()
+	g.p("mu sync.Mutex")
+	for _, m := range intf.Methods {
+		field := fakeFieldPrefix(m.Name)
+		g.p("")
+		g.p("%vStub func(%v)%v", field, strings.Join(g.getArgTypes(m, outputPackagePath, true /* in */), ", "), g.fakeRetString(m, outputPackagePath))
+		if argFields := g.fakeArgFields(m, outputPackagePath); argFields != "" {
+			g.p("%vArgsForCall []struct{ %v }", field, argFields)
+		} else {
+			g.p("%vCallCount int", field)
+		}
+		if retFields := g.fakeRetFields(m, outputPackagePath); retFields != "" {
+			g.p("%vReturns struct{ %v }", field, retFields)
+		}
+	}
+	g.out()
+	g.p("}")
+
+	if intf.FuncType {
+		g.GenerateMockFuncMethod(fakeType, "fake", intf, outputPackagePath, shortTp)
+	}
+
+	for _, m := range intf.Methods {
+		g.p("")
+		g.GenerateFakeMethod(fakeType, m, outputPackagePath, shortTp)
+	}
+
+	return nil
+}
+
+// GenerateFakeMethod generates the implementation of m on the fake and its
+// CallCount, Calls, ArgsForCall and Returns helpers.
+func (g *generator) GenerateFakeMethod(fakeType string, m *model.Method, pkgOverride, shortTp string) {
+	argNames := g.getArgNames(m, true /* in */)
+	argTypes := g.getArgTypes(m, pkgOverride, true /* in */)
+	argFields := g.fakeArgFields(m, pkgOverride)
+	retFields := g.fakeRetFields(m, pkgOverride)
+	retString := g.fakeRetString(m, pkgOverride)
+	field := fakeFieldPrefix(m.Name)
+	recv := fakeType + shortTp
+
+	ia := newIdentifierAllocator(argNames)
+	idRecv := ia.allocateIdentifier("fake")
+	idStub := ia.allocateIdentifier("stub")
+	idRets := ia.allocateIdentifier("returns")
+
+	callArgs := strings.Join(argNames, ", ")
+	if m.Variadic != nil {
+		callArgs += "..."
+	}
+
+	g.p("// %v implements the faked method.", m.Name)
+	g.docComment(m.Doc)
+	g.p("func (%v *%v) %v(%v)%v {", idRecv, recv, m.Name, makeArgString(argNames, argTypes), retString)
+	g.in()
+	g.p("%v.mu.Lock()", idRecv)
+	if argFields != "" {
+		g.p("%v.%vArgsForCall = append(%v.%vArgsForCall, struct{ %v }{%v})", idRecv, field, idRecv, field, argFields, strings.Join(argNames, ", "))
+	} else {
+		g.p("%v.%vCallCount++", idRecv, field)
+	}
+	g.p("%v := %v.%vStub", idStub, idRecv, field)
+	if retFields != "" {
+		g.p("%v := %v.%vReturns", idRets, idRecv, field)
+	}
+	g.p("%v.mu.Unlock()", idRecv)
+	g.p("if %v != nil {", idStub)
+	g.in()
+	if len(m.Out) > 0 {
+		g.p("return %v(%v)", idStub, callArgs)
+	} else {
+		g.p("%v(%v)", idStub, callArgs)
+	}
+	g.out()
+	g.p("}")
+	if len(m.Out) > 0 {
+		rets := make([]string, len(m.Out))
+		for i := range m.Out {
+			rets[i] = fmt.Sprintf("%v.result%d", idRets, i+1)
+		}
+		g.p("return %v", strings.Join(rets, ", "))
+	}
+	g.out()
+	g.p("}")
+
+	g.p("")
+	g.p("// %vCallCount returns the number of calls to %v.", m.Name, m.Name)
+	g.p("func (fake *%v) %vCallCount() int {", recv, m.Name)
+	g.in()
+	g.p("fake.mu.Lock()")
+	g.p("defer fake.mu.Unlock()")
+	if argFields != "" {
+		g.p("return len(fake.%vArgsForCall)", field)
+	} else {
+		g.p("return fake.%vCallCount", field)
+	}
+	g.out()
+	g.p("}")
+
+	g.p("")
+	if retFields != "" {
+		g.p("// %vCalls makes %v delegate to stub, which takes precedence over %vReturns.", m.Name, m.Name, m.Name)
+	} else {
+		g.p("// %vCalls makes %v delegate to stub.", m.Name, m.Name)
+	}
+	g.p("func (fake *%v) %vCalls(stub func(%v)%v) {", recv, m.Name, strings.Join(argTypes, ", "), retString)
+	g.in()
+	g.p("fake.mu.Lock()")
+	g.p("defer fake.mu.Unlock()")
+	g.p("fake.%vStub = stub", field)
+	g.out()
+	g.p("}")
+
+	if argFields != "" {
+		fieldTypes := g.fakeArgFieldTypes(m, pkgOverride)
+		rets := make([]string, len(fieldTypes))
+		for i := range fieldTypes {
+			rets[i] = fmt.Sprintf("args.arg%d", i+1)
+		}
+		retTypes := strings.Join(fieldTypes, ", ")
+		if len(fieldTypes) > 1 {
+			retTypes = "(" + retTypes + ")"
+		}
+
+		g.p("")
+		g.p("// %vArgsForCall returns the arguments of the i-th call to %v.", m.Name, m.Name)
+		g.p("func (fake *%v) %vArgsForCall(i int) %v {", recv, m.Name, retTypes)
+		g.in()
+		g.p("fake.mu.Lock()")
+		g.p("defer fake.mu.Unlock()")
+		g.p("args := fake.%vArgsForCall[i]", field)
+		g.p("return %v", strings.Join(rets, ", "))
+		g.out()
+		g.p("}")
+	}
+
+	if retFields != "" {
+		names := make([]string, len(m.Out))
+		for i := range m.Out {
+			names[i] = fmt.Sprintf("result%d", i+1)
+		}
+
+		g.p("")
+		g.p("// %vReturns makes %v return the given values.", m.Name, m.Name)
+		g.p("func (fake *%v) %vReturns(%v) {", recv, m.Name, makeArgString(names, g.fakeRetTypes(m, pkgOverride)))
+		g.in()
+		g.p("fake.mu.Lock()")
+		g.p("defer fake.mu.Unlock()")
+		g.p("fake.%vStub = nil", field)
+		g.p("fake.%vReturns = struct{ %v }{%v}", field, retFields, strings.Join(names, ", "))
+		g.out()
+		g.p("}")
+	}
+}
+
+// fakeArgFieldTypes returns the types of the recorded arguments of m.
+// A variadic argument is recorded as a slice.
+func (g *generator) fakeArgFieldTypes(m *model.Method, pkgOverride string) []string {
+	types := make([]string, 0, len(m.In)+1)
+	for _, p := range m.In {
+		types = append(types, p.Type.String(g.packageMap, pkgOverride))
+	}
+	if m.Variadic != nil {
+		types = append(types, "[]"+m.Variadic.Type.String(g.packageMap, pkgOverride))
+	}
+	return types
+}
+
+// fakeArgFields returns the field list of the struct recording the arguments
+// of a call to m.
+func (g *generator) fakeArgFields(m *model.Method, pkgOverride string) string {
+	types := g.fakeArgFieldTypes(m, pkgOverride)
+	fields := make([]string, len(types))
+	for i, t := range types {
+		fields[i] = fmt.Sprintf("arg%d %v", i+1, t)
+	}
+	return strings.Join(fields, "; ")
+}
+
+// fakeRetFields returns the field list of the struct holding the values
+// returned by m.
+func (g *generator) fakeRetFields(m *model.Method, pkgOverride string) string {
+	fields := make([]string, len(m.Out))
+	for i, p := range m.Out {
+		fields[i] = fmt.Sprintf("result%d %v", i+1, p.Type.String(g.packageMap, pkgOverride))
+	}
+	return strings.Join(fields, "; ")
+}
+
+func (g *generator) fakeRetTypes(m *model.Method, pkgOverride string) []string {
+	rets := make([]string, len(m.Out))
+	for i, p := range m.Out {
+		rets[i] = p.Type.String(g.packageMap, pkgOverride)
+	}
+	return rets
+}
+
+func (g *generator) fakeRetString(m *model.Method, pkgOverride string) string {
+	rets := g.fakeRetTypes(m, pkgOverride)
+	switch len(rets) {
+	case 0:
+		return ""
+	case 1:
+		return " " + rets[0]
+	default:
+		return " (" + strings.Join(rets, ", ") + ")"
+	}
+}
+
+// fakeFieldPrefix returns the prefix of the unexported fields holding the
+// state of method name.
+func fakeFieldPrefix(name string) string {
+	r, n := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[n:]
+}
@@ -0,0 +1,224 @@
+// Package generate generates mock implementations of Go interfaces.
+//
+// It is the library behind the mockgen command: a model of the interfaces to
+// mock is loaded with [ParseSource], [LoadPackage] or [LoadArchive], and the
+// mocks are generated from the model with [Generate].
+//
+// The symbols to load may name instantiations of generic interfaces and
+// function types, such as Repo[example.com/models.User]. The type arguments are
+// substituted for the type parameters, and a non-generic mock named after the
+// instantiation, e.g. MockUserRepo, is generated. Named type arguments are
+// qualified by their import path unless they are predeclared or declared in
+// the package of the generic type.
+package generate
+
+import (
+	"log"
+	"path/filepath"
+
+	"go.uber.org/mock/mockgen/model"
+)
+
+// Styles of the generated code.
+const (
+	// StyleMock generates gomock mocks, which check expectations.
+	StyleMock = "mock"
+	// StyleFake generates counterfeiter-style fakes, which record their
+	// calls and return configurable values.
+	StyleFake = "fake"
+)
+
+// LoadOptions configures the loading of the interfaces to mock.
+type LoadOptions struct {
+	// BuildFlags are additional flags for go build (package mode). In source
+	// mode, -tags selects the files to parse like go build does.
+	BuildFlags []string
+	// ExtractInterfaces accepts concrete types among the symbols (archive
+	// and package mode). An interface is extracted from the exported method
+	// set of a pointer to the type.
+	ExtractInterfaces bool
+
+	// Imports maps package names to the import paths to use for them
+	// (source mode).
+	Imports map[string]string
+	// DotImports are the import paths of additional dot imports
+	// (source mode).
+	DotImports []string
+	// AuxFiles are auxiliary source files declaring embedded interfaces
+	// (source mode).
+	AuxFiles []AuxFile
+	// ExcludeInterfaces are the names of the interfaces not to mock
+	// (source mode).
+	ExcludeInterfaces []string
+	// Hermetic type-checks the package and its dependencies from source
+	// instead of building them with the go command (package mode). Imports
+	// are resolved from the go.mod file of the module containing the working
+	// directory, its vendor directory, GOROOT and the module cache, and -tags
+	// in BuildFlags select the files. The model is the same as with the go
+	// command, except that positions also have columns.
+	Hermetic bool
+	// ArchiveDeps maps the import paths of dependencies to their export data
+	// files, as the packagefile lines of a compiler importcfg (archive mode).
+	// Composed interfaces of these packages are loaded from them instead of
+	// with the go command. A file is only read when objects of its package
+	// that are missing from the export data read so far are needed.
+	ArchiveDeps map[string]string
+	// SkipUnsupported skips, and logs, the interfaces that cannot be modeled,
+	// such as those with methods taking non-empty unnamed interfaces, instead
+	// of failing.
+	SkipUnsupported bool
+}
+
+// An AuxFile is an auxiliary source file of the package Package.
+type AuxFile struct {
+	Package string
+	Path    string
+}
+
+// ParseSource parses the interfaces declared in source, restricted to symbols
+// if it is not empty. source is either a source file, or the directory or
+// import path of a package, in which case all of its non-test files are parsed.
+func ParseSource(source string, symbols []string, opts LoadOptions) (*model.Package, error) {
+	return loadInstances(symbols, func(symbols []string) (*model.Package, error) {
+		return sourceMode(source, symbols, opts)
+	})
+}
+
+// LoadPackage loads the interfaces named symbols from the package importPath
+// using the go command, or from source if opts.Hermetic is set.
+func LoadPackage(importPath string, symbols []string, opts LoadOptions) (*model.Package, error) {
+	parser := packageModeParser{opts: opts}
+	return loadInstances(symbols, func(symbols []string) (*model.Package, error) {
+		return parser.parsePackage(importPath, symbols)
+	})
+}
+
+// ExportFile builds the package importPath and returns the path to its export
+// data, which can be passed to [LoadArchive].
+func ExportFile(importPath string, opts LoadOptions) (string, error) {
+	parser := packageModeParser{opts: opts}
+	return parser.exportFile(importPath)
+}
+
+// LoadArchive loads the interfaces named symbols from the export data of the
+// package importPath in archive. If symbols is empty, all interfaces of the
+// package are loaded.
+func LoadArchive(archive, importPath string, symbols []string, opts LoadOptions) (*model.Package, error) {
+	parser := packageModeParser{opts: opts}
+	return loadInstances(symbols, func(symbols []string) (*model.Package, error) {
+		return parser.parseExportFile(importPath, symbols, archive)
+	})
+}
+
+// PackageImportPath returns the import path of the package in dir.
+func PackageImportPath(dir string) (string, error) {
+	return parsePackageImport(dir)
+}
+
+// Options configures the generated code.
+type Options struct {
+	// PackageName is the package of the generated code. It defaults to the
+	// package of the input with a "mock_" prefix.
+	PackageName string
+	// SelfPackage is the import path of the generated code. It is inferred
+	// from Destination if empty.
+	SelfPackage string
+	// Destination is the file the generated code will be written to.
+	// It may be empty.
+	Destination string
+
+	// MockNames maps interface names to the names of their mocks.
+	// Mock names default to "Mock" + interface name.
+	MockNames map[string]string
+	// Imports maps package names to the import paths to use for them.
+	Imports map[string]string
+	// Style is the style of the generated code, StyleMock if empty.
+	Style string
+	// Typed generates type-safe Return, Do and DoAndReturn methods.
+	Typed bool
+	// ArgBuilders, if positive, generates a builder of expected calls with
+	// named arguments, e.g. EXPECT().FooWith().UserID(x).Call(), for the
+	// methods with at least ArgBuilders parameters.
+	ArgBuilders int
+
+	// CopyrightHeader is written as a comment at the top of the file.
+	CopyrightHeader string
+	// BuildConstraint, if not empty, is written as a //go:build constraint.
+	BuildConstraint string
+	// Source, if not empty, describes the input in a comment.
+	Source string
+	// Command is the command line generating the code, starting with the
+	// executable.
+	Command []string
+	// WriteCommandComment writes Command in a comment.
+	WriteCommandComment bool
+	// WritePackageComment writes a package documentation comment.
+	WritePackageComment bool
+	// WriteGenerateDirective writes a //go:generate directive running Command.
+	WriteGenerateDirective bool
+	// Template is the text/template of the generated code, DefaultTemplate
+	// if empty.
+	Template string
+	// DocComments copies the doc comments of the mocked interfaces and
+	// methods. The missing doc comments are read from the source files at the
+	// positions recorded in pkg.
+	DocComments bool
+}
+
+// Generate generates the mocks of the interfaces of pkg.
+func Generate(pkg *model.Package, opts Options) ([]byte, error) {
+	outputPackageName := opts.PackageName
+	if outputPackageName == "" {
+		// pkg.Name in package mode is the base name of the import path,
+		// which might have characters that are illegal to have in package names.
+		outputPackageName = "mock_" + sanitize(pkg.Name)
+	}
+
+	// outputPackagePath represents the fully qualified name of the package of
+	// the generated code. Its purposes are to prevent the module from importing
+	// itself and to prevent qualifying type names that come from its own
+	// package (i.e. if there is a type called X then we want to print "X" not
+	// "package.X" since "package" is this package). This can happen if the mock
+	// is output into an already existing package.
+	outputPackagePath := opts.SelfPackage
+	if outputPackagePath == "" && opts.Destination != "" {
+		dstPath, err := filepath.Abs(filepath.Dir(opts.Destination))
+		if err == nil {
+			pkgPath, err := parsePackageImport(dstPath)
+			if err == nil {
+				outputPackagePath = pkgPath
+			} else {
+				log.Println("Unable to infer -self_package from destination file path:", err)
+			}
+		} else {
+			log.Println("Unable to determine destination file path:", err)
+		}
+	}
+
+	if opts.DocComments {
+		loadDocComments(pkg)
+	}
+
+	g := &generator{
+		mockNames:              opts.MockNames,
+		source:                 opts.Source,
+		destination:            opts.Destination,
+		selfPackage:            opts.SelfPackage,
+		command:                opts.Command,
+		imports:                opts.Imports,
+		copyrightHeader:        opts.CopyrightHeader,
+		buildConstraint:        opts.BuildConstraint,
+		docComments:            opts.DocComments,
+		style:                  opts.Style,
+		typed:                  opts.Typed,
+		argBuilders:            opts.ArgBuilders,
+		template:               opts.Template,
+		writeCmdComment:        opts.WriteCommandComment,
+		writePkgComment:        opts.WritePackageComment,
+		writeGenerateDirective: opts.WriteGenerateDirective,
+	}
+	if err := g.Generate(pkg, outputPackageName, outputPackagePath); err != nil {
+		return nil, err
+	}
+	return g.Output()
+}
@@ -5,7 +5,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package main
+package generate
 
 import (
 	"errors"
@@ -25,6 +25,9 @@ func getTypeSpecTypeParams(ts *ast.TypeSpec) []*ast.Field {
 
 func (p *fileParser) parseGenericType(pkg string, typ ast.Expr, tps map[string]model.Type) (model.Type, error) {
 	switch v := typ.(type) {
+	case *ast.BinaryExpr, *ast.UnaryExpr:
+		// A type set such as ~int | ~float64, which is a constraint.
+		return p.parseUnion(pkg, v, tps)
 	case *ast.IndexExpr:
 		m, err := p.parseType(pkg, v.X, tps)
 		if err != nil {
@@ -63,6 +66,42 @@ func (p *fileParser) parseGenericType(pkg string, typ ast.Expr, tps map[string]m
 	return nil, nil
 }
 
+// parseUnion parses the type set expr, such as ~int | ~float64.
+func (p *fileParser) parseUnion(pkg string, expr ast.Expr, tps map[string]model.Type) (*model.UnionType, error) {
+	union := &model.UnionType{}
+	var addTerms func(expr ast.Expr) error
+	addTerms = func(expr ast.Expr) error {
+		term := &model.UnionTerm{}
+		switch v := expr.(type) {
+		case *ast.BinaryExpr:
+			if v.Op != token.OR {
+				return p.errorf(v.OpPos, "unexpected operator %v in type set", v.Op)
+			}
+			if err := addTerms(v.X); err != nil {
+				return err
+			}
+			return addTerms(v.Y)
+		case *ast.UnaryExpr:
+			if v.Op != token.TILDE {
+				return p.errorf(v.OpPos, "unexpected operator %v in type set", v.Op)
+			}
+			term.Tilde = true
+			expr = v.X
+		}
+		t, err := p.parseType(pkg, expr, tps)
+		if err != nil {
+			return err
+		}
+		term.Type = t
+		union.Terms = append(union.Terms, term)
+		return nil
+	}
+	if err := addTerms(expr); err != nil {
+		return nil, err
+	}
+	return union, nil
+}
+
 func (p *fileParser) parseGenericMethod(field *ast.Field, it *namedInterface, iface *model.Interface, pkg string, tps map[string]model.Type) ([]*model.Method, error) {
 	var indices []ast.Expr
 	var typ ast.Expr
@@ -77,12 +116,12 @@ func (p *fileParser) parseGenericMethod(field *ast.Field, it *namedInterface, if
 		if v.Op == token.TILDE {
 			return nil, errConstraintInterface
 		}
-		return nil, fmt.Errorf("~T may only appear as constraint for %T", field.Type)
+		return nil, unsupported(fmt.Errorf("~T may only appear as constraint for %T", field.Type))
 	case *ast.BinaryExpr:
 		if v.Op == token.OR {
 			return nil, errConstraintInterface
 		}
-		return nil, fmt.Errorf("A|B may only appear as constraint for %T", field.Type)
+		return nil, unsupported(fmt.Errorf("A|B may only appear as constraint for %T", field.Type))
 	default:
 		return nil, fmt.Errorf("don't know how to mock method of type %T", field.Type)
 	}
@@ -0,0 +1,424 @@
+package generate
+
+// This file contains the hermetic package mode backend, which type-checks the
+// package and its dependencies from source instead of building it with the go
+// command.
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+
+	"go.uber.org/mock/mockgen/model"
+)
+
+// parseSourcePackage loads the interfaces ifaces of the package packageName
+// by type-checking it from source.
+func (p *packageModeParser) parseSourcePackage(packageName string, ifaces []string) (*model.Package, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	l, err := newSourceLoader(buildContext(p.opts.BuildFlags), wd)
+	if err != nil {
+		return nil, fmt.Errorf("load package: %w", err)
+	}
+	tp, err := l.loadTarget(packageName)
+	if err != nil {
+		return nil, fmt.Errorf("load package: %w", err)
+	}
+
+	interfaces, err := extractInterfacesFromPackageTypes(l.fset, tp, ifaces, p.opts)
+	if err != nil {
+		return nil, fmt.Errorf("extract interfaces from package: %w", err)
+	}
+	return &model.Package{
+		Name:       tp.Name(),
+		PkgPath:    tp.Path(),
+		Interfaces: interfaces,
+	}, nil
+}
+
+// A sourceLoader type-checks packages from source. Import paths are resolved
+// from the go.mod file of the main module, or the go.work file of its
+// workspace, the vendor directory, GOROOT and the module cache, without the go
+// command.
+type sourceLoader struct {
+	ctxt *build.Context
+	fset *token.FileSet
+	mod  *mainModule               // nil outside of a module
+	pkgs map[string]*types.Package // by directory
+}
+
+// A mainModule is the module containing the working directory. In a
+// workspace, the other modules of the workspace are among its requirements.
+type mainModule struct {
+	path      string // empty if the working directory is outside the modules of a workspace
+	dir       string
+	vendorDir string            // set if vendor/modules.txt exists
+	requires  map[string]string // module path => directory
+}
+
+func newSourceLoader(ctxt *build.Context, wd string) (*sourceLoader, error) {
+	mod, err := findMainModule(wd)
+	if err != nil {
+		return nil, err
+	}
+	return &sourceLoader{
+		ctxt: ctxt,
+		fset: token.NewFileSet(),
+		mod:  mod,
+		pkgs: make(map[string]*types.Package),
+	}, nil
+}
+
+// findMainModule reads the go.mod file of the module containing dir, or the
+// go.work file of the workspace containing dir and the go.mod files of its
+// modules. Like the go command, it honours the GOWORK environment variable.
+func findMainModule(dir string) (*mainModule, error) {
+	workFile, err := findWorkFile(dir)
+	if err != nil {
+		return nil, err
+	}
+	if workFile != "" {
+		data, err := os.ReadFile(workFile)
+		if err != nil {
+			return nil, err
+		}
+		return parseWorkspace(workFile, data, dir)
+	}
+	for {
+		data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			return parseMainModule(dir, data)
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// findWorkFile returns the go.work file of the workspace containing dir, or
+// the one set by GOWORK, if any.
+func findWorkFile(dir string) (string, error) {
+	switch gowork := os.Getenv("GOWORK"); gowork {
+	case "off":
+		return "", nil
+	case "":
+	default:
+		if !filepath.IsAbs(gowork) {
+			return "", fmt.Errorf("invalid GOWORK: %s is not an absolute path", gowork)
+		}
+		return gowork, nil
+	}
+	for {
+		workFile := filepath.Join(dir, "go.work")
+		if fi, err := os.Stat(workFile); err == nil && !fi.IsDir() {
+			return workFile, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+func parseMainModule(dir string, data []byte) (*mainModule, error) {
+	f, err := parseModFile(dir, data)
+	if err != nil {
+		return nil, err
+	}
+	versions := make(map[string]string)
+	for _, r := range f.Require {
+		versions[r.Mod.Path] = r.Mod.Version
+	}
+	var replaces []replacement
+	for _, r := range f.Replace {
+		replaces = append(replaces, replacement{r, dir})
+	}
+	requires, err := requireDirs(versions, replaces)
+	if err != nil {
+		return nil, err
+	}
+	return &mainModule{
+		path:      f.Module.Mod.Path,
+		dir:       dir,
+		vendorDir: vendorDir(dir),
+		requires:  requires,
+	}, nil
+}
+
+// parseWorkspace reads the go.work file workFile with contents data and the
+// go.mod files of the modules it uses. The main module is the one containing
+// wd. The highest version of a module required by any of the modules is
+// selected, and the replacements of go.work override those of the modules.
+func parseWorkspace(workFile string, data []byte, wd string) (*mainModule, error) {
+	wf, err := modfile.ParseWork(workFile, data, nil)
+	if err != nil {
+		return nil, err
+	}
+	workDir := filepath.Dir(workFile)
+	mod := &mainModule{vendorDir: vendorDir(workDir)}
+	versions := make(map[string]string)
+	var replaces []replacement
+	modDirs := make(map[string]string)
+	for _, u := range wf.Use {
+		dir := filepath.FromSlash(u.Path)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(workDir, dir)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", workFile, err)
+		}
+		f, err := parseModFile(dir, data)
+		if err != nil {
+			return nil, err
+		}
+		modDirs[f.Module.Mod.Path] = dir
+		if hasDirPrefix(wd, dir) && len(dir) > len(mod.dir) {
+			mod.path, mod.dir = f.Module.Mod.Path, dir
+		}
+		for _, r := range f.Require {
+			if v, ok := versions[r.Mod.Path]; !ok || semver.Compare(r.Mod.Version, v) > 0 {
+				versions[r.Mod.Path] = r.Mod.Version
+			}
+		}
+		for _, r := range f.Replace {
+			replaces = append(replaces, replacement{r, dir})
+		}
+	}
+	for _, r := range wf.Replace {
+		replaces = append(replaces, replacement{r, workDir})
+	}
+	if mod.requires, err = requireDirs(versions, replaces); err != nil {
+		return nil, err
+	}
+	// The modules of the workspace are used instead of any required version.
+	for path, dir := range modDirs {
+		mod.requires[path] = dir
+	}
+	return mod, nil
+}
+
+func parseModFile(dir string, data []byte) (*modfile.File, error) {
+	f, err := modfile.Parse(filepath.Join(dir, "go.mod"), data, nil)
+	if err != nil {
+		return nil, err
+	}
+	if f.Module == nil {
+		return nil, fmt.Errorf("%s: no module directive", filepath.Join(dir, "go.mod"))
+	}
+	return f, nil
+}
+
+// vendorDir returns the vendor directory of the module or workspace in dir,
+// or the empty string if it is not vendored.
+func vendorDir(dir string) string {
+	vendor := filepath.Join(dir, "vendor")
+	if _, err := os.Stat(filepath.Join(vendor, "modules.txt")); err != nil {
+		return ""
+	}
+	return vendor
+}
+
+// A replacement is a replace directive of the go.mod or go.work file in dir.
+type replacement struct {
+	*modfile.Replace
+	dir string
+}
+
+// requireDirs returns the directories of the modules required in the
+// selected versions, after applying the replacements in order.
+func requireDirs(versions map[string]string, replaces []replacement) (map[string]string, error) {
+	modCache := os.Getenv("GOMODCACHE")
+	if modCache == "" {
+		modCache = filepath.Join(filepath.SplitList(build.Default.GOPATH)[0], "pkg", "mod")
+	}
+	cacheDir := func(m module.Version) (string, error) {
+		path, err := module.EscapePath(m.Path)
+		if err != nil {
+			return "", err
+		}
+		version, err := module.EscapeVersion(m.Version)
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(modCache, path+"@"+version), nil
+	}
+	requires := make(map[string]string, len(versions))
+	for path, version := range versions {
+		var err error
+		if requires[path], err = cacheDir(module.Version{Path: path, Version: version}); err != nil {
+			return nil, err
+		}
+	}
+	for _, r := range replaces {
+		if version, ok := versions[r.Old.Path]; !ok || (r.Old.Version != "" && r.Old.Version != version) {
+			continue
+		}
+		if r.New.Version == "" {
+			// A directory replacement.
+			newDir := r.New.Path
+			if !filepath.IsAbs(newDir) {
+				newDir = filepath.Join(r.dir, newDir)
+			}
+			requires[r.Old.Path] = newDir
+			continue
+		}
+		var err error
+		if requires[r.Old.Path], err = cacheDir(r.New); err != nil {
+			return nil, err
+		}
+	}
+	return requires, nil
+}
+
+// loadTarget type-checks the package named by the import path or the
+// relative or absolute directory target.
+func (l *sourceLoader) loadTarget(target string) (*types.Package, error) {
+	if build.IsLocalImport(target) || filepath.IsAbs(target) {
+		dir, err := filepath.Abs(target)
+		if err != nil {
+			return nil, err
+		}
+		importPath, err := parsePackageImport(dir)
+		if err != nil {
+			return nil, err
+		}
+		return l.load(importPath, dir, true)
+	}
+	importPath, dir, err := l.resolve(target, "")
+	if err != nil {
+		return nil, err
+	}
+	return l.load(importPath, dir, true)
+}
+
+// resolve returns the import path and the directory of the package imported
+// as path by a package in srcDir.
+func (l *sourceLoader) resolve(path, srcDir string) (string, string, error) {
+	goroot := filepath.Join(l.ctxt.GOROOT, "src")
+	if srcDir != "" && hasDirPrefix(srcDir, goroot) {
+		// The standard library vendors its dependencies.
+		dir := filepath.Join(goroot, "vendor", filepath.FromSlash(path))
+		if isDir(dir) {
+			return "vendor/" + path, dir, nil
+		}
+	}
+	if first, _, _ := strings.Cut(path, "/"); !strings.Contains(first, ".") {
+		dir := filepath.Join(goroot, filepath.FromSlash(path))
+		if isDir(dir) {
+			return path, dir, nil
+		}
+	}
+	if l.mod == nil {
+		return "", "", fmt.Errorf("cannot find package %s outside of a module", path)
+	}
+	if l.mod.vendorDir != "" {
+		dir := filepath.Join(l.mod.vendorDir, filepath.FromSlash(path))
+		if isDir(dir) {
+			return path, dir, nil
+		}
+	}
+	if l.mod.path != "" && (path == l.mod.path || strings.HasPrefix(path, l.mod.path+"/")) {
+		return path, filepath.Join(l.mod.dir, filepath.FromSlash(strings.TrimPrefix(path, l.mod.path))), nil
+	}
+	// The longest module path providing the package.
+	modPath := ""
+	for p := range l.mod.requires {
+		if (path == p || strings.HasPrefix(path, p+"/")) && len(p) > len(modPath) {
+			modPath = p
+		}
+	}
+	if modPath == "" {
+		return "", "", fmt.Errorf("no required module provides package %s", path)
+	}
+	return path, filepath.Join(l.mod.requires[modPath], filepath.FromSlash(strings.TrimPrefix(path, modPath))), nil
+}
+
+// load type-checks the package importPath in dir. Only the errors of the
+// target package are reported: the errors of dependencies are mostly due to
+// cgo, which is not run, and do not affect their exported API.
+func (l *sourceLoader) load(importPath, dir string, target bool) (*types.Package, error) {
+	if tp, ok := l.pkgs[dir]; ok {
+		return tp, nil
+	}
+	bp, err := l.ctxt.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, name := range append(bp.GoFiles, bp.CgoFiles...) {
+		f, err := parser.ParseFile(l.fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	var errs []error
+	conf := types.Config{
+		Importer:         importerFunc(l.importFrom),
+		FakeImportC:      true,
+		IgnoreFuncBodies: true,
+		Sizes:            types.SizesFor("gc", l.ctxt.GOARCH),
+		Error: func(err error) {
+			errs = append(errs, err)
+		},
+	}
+	tp, _ := conf.Check(importPath, l.fset, files, nil)
+	if target && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	l.pkgs[dir] = tp
+	return tp, nil
+}
+
+func (l *sourceLoader) importFrom(path, srcDir string) (*types.Package, error) {
+	if path == "unsafe" {
+		return types.Unsafe, nil
+	}
+	importPath, dir, err := l.resolve(path, srcDir)
+	if err != nil {
+		return nil, err
+	}
+	return l.load(importPath, dir, false)
+}
+
+// importerFunc implements types.ImporterFrom with a function.
+type importerFunc func(path, srcDir string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) {
+	return f(path, "")
+}
+
+func (f importerFunc) ImportFrom(path, srcDir string, _ types.ImportMode) (*types.Package, error) {
+	return f(path, srcDir)
+}
+
+func hasDirPrefix(dir, prefix string) bool {
+	return dir == prefix || strings.HasPrefix(dir, prefix+string(filepath.Separator))
+}
+
+func isDir(dir string) bool {
+	fi, err := os.Stat(dir)
+	return err == nil && fi.IsDir()
+}
@@ -0,0 +1,174 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHermeticPackageMode(t *testing.T) {
+	const packageName = "go.uber.org/mock/mockgen/internal/tests/package_mode"
+	ifaces := []string{"Food", "Eater", "Animal", "Human", "Primate", "Car", "Driver", "UrbanResident", "Farmer", "Earth"}
+
+	want, err := LoadPackage(packageName, ifaces, LoadOptions{})
+	require.NoError(t, err)
+
+	// The go command is not needed.
+	t.Setenv("PATH", "")
+	got, err := LoadPackage(packageName, ifaces, LoadOptions{Hermetic: true})
+	require.NoError(t, err)
+
+	// Export data only records the lines of positions.
+	require.Len(t, got.Interfaces, len(want.Interfaces))
+	for i, intf := range got.Interfaces {
+		assert.Equal(t, want.Interfaces[i].Pos.Filename, intf.Pos.Filename)
+		assert.Equal(t, want.Interfaces[i].Pos.Line, intf.Pos.Line)
+	}
+	clearPositions(want)
+	clearPositions(got)
+	assert.Equal(t, want, got)
+}
+
+func TestHermeticPackageMode_Errors(t *testing.T) {
+	opts := LoadOptions{Hermetic: true}
+	_, err := LoadPackage("example.com/missing", []string{"Store"}, opts)
+	assert.ErrorContains(t, err, "no required module provides package example.com/missing")
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/broken\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.go"), []byte("package broken\n\ntype Store interface{ Get() Missing }\n"), 0o644))
+	_, err = LoadPackage(dir, []string{"Store"}, opts)
+	assert.ErrorContains(t, err, "undefined: Missing")
+}
+
+func TestParseMainModule(t *testing.T) {
+	t.Setenv("GOMODCACHE", "/cache")
+	mod, err := parseMainModule("/src/x", []byte(`module example.com/x
+
+require (
+	example.com/a v1.0.0
+	example.com/Upper v1.2.0
+	example.com/local v0.1.0
+	example.com/fork v1.0.0
+	example.com/pinned v1.0.0
+)
+
+replace example.com/local => ../local
+
+replace example.com/fork => example.com/other v2.0.0
+
+replace example.com/pinned v0.9.0 => ../pinned
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/x", mod.path)
+	assert.Equal(t, map[string]string{
+		"example.com/a":      filepath.FromSlash("/cache/example.com/a@v1.0.0"),
+		"example.com/Upper":  filepath.FromSlash("/cache/example.com/!upper@v1.2.0"),
+		"example.com/local":  filepath.FromSlash("/src/local"),
+		"example.com/fork":   filepath.FromSlash("/cache/example.com/other@v2.0.0"),
+		"example.com/pinned": filepath.FromSlash("/cache/example.com/pinned@v1.0.0"),
+	}, mod.requires)
+
+	l := &sourceLoader{ctxt: buildContext(nil), mod: mod}
+	for _, tc := range []struct {
+		path, wantPath, wantDir string
+	}{
+		{"example.com/x", "example.com/x", "/src/x"},
+		{"example.com/x/y", "example.com/x/y", "/src/x/y"},
+		{"example.com/a/b/c", "example.com/a/b/c", "/cache/example.com/a@v1.0.0/b/c"},
+		{"example.com/local/sub", "example.com/local/sub", "/src/local/sub"},
+		{"io", "io", filepath.Join(l.ctxt.GOROOT, "src", "io")},
+	} {
+		gotPath, gotDir, err := l.resolve(tc.path, "")
+		require.NoError(t, err, tc.path)
+		assert.Equal(t, tc.wantPath, gotPath)
+		assert.Equal(t, filepath.FromSlash(tc.wantDir), gotDir)
+	}
+	_, _, err = l.resolve("example.com/ab", "")
+	assert.EqualError(t, err, "no required module provides package example.com/ab")
+}
+
+func TestParseWorkspace(t *testing.T) {
+	t.Setenv("GOMODCACHE", "/cache")
+	dir := t.TempDir()
+	for name, data := range map[string]string{
+		"a/go.mod": `module example.com/a
+
+require (
+	example.com/b v1.0.0
+	example.com/c v1.1.0
+)
+
+replace example.com/d => ../d
+`,
+		"b/go.mod": `module example.com/b
+
+require (
+	example.com/c v1.2.0
+	example.com/d v1.0.0
+	example.com/e v1.0.0
+)
+`,
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644))
+	}
+	workFile := filepath.Join(dir, "go.work")
+	mod, err := parseWorkspace(workFile, []byte(`go 1.23
+
+use (
+	./a
+	./b
+)
+
+replace example.com/e => example.com/fork v2.0.0
+`), filepath.Join(dir, "a", "sub"))
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/a", mod.path)
+	assert.Equal(t, filepath.Join(dir, "a"), mod.dir)
+	assert.Equal(t, map[string]string{
+		"example.com/a": filepath.Join(dir, "a"),
+		"example.com/b": filepath.Join(dir, "b"),
+		"example.com/c": filepath.FromSlash("/cache/example.com/c@v1.2.0"),
+		"example.com/d": filepath.Join(dir, "d"),
+		"example.com/e": filepath.FromSlash("/cache/example.com/fork@v2.0.0"),
+	}, mod.requires)
+
+	mod, err = parseWorkspace(workFile, []byte("go 1.23\n\nuse ./a\n"), dir)
+	require.NoError(t, err)
+	assert.Empty(t, mod.path)
+
+	_, err = parseWorkspace(workFile, []byte("go 1.23\n\nuse ./missing\n"), dir)
+	assert.Error(t, err)
+}
+
+func TestHermeticPackageMode_Workspace(t *testing.T) {
+	dir := t.TempDir()
+	for name, data := range map[string]string{
+		"go.work":      "go 1.23\n\nuse (\n\t./app\n\t./lib\n)\n",
+		"app/go.mod":   "module example.com/app\n",
+		"app/store.go": "package app\n\nimport \"example.com/lib\"\n\ntype Store interface{ Get() lib.Item }\n",
+		"lib/go.mod":   "module example.com/lib\n",
+		"lib/item.go":  "package lib\n\ntype Item struct{}\n",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644))
+	}
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(filepath.Join(dir, "app")))
+	t.Cleanup(func() { os.Chdir(wd) })
+	t.Setenv("GOWORK", "")
+
+	pkg, err := LoadPackage(".", []string{"Store"}, LoadOptions{Hermetic: true})
+	require.NoError(t, err)
+	require.Len(t, pkg.Interfaces, 1)
+	assert.Equal(t, "lib.Item", pkg.Interfaces[0].Methods[0].Out[0].Type.String(map[string]string{"example.com/lib": "lib"}, ""))
+
+	t.Setenv("GOWORK", "off")
+	_, err = LoadPackage(".", []string{"Store"}, LoadOptions{Hermetic: true})
+	assert.ErrorContains(t, err, "no required module provides package example.com/lib")
+}
@@ -0,0 +1,391 @@
+package generate
+
+// This file contains the support for mocking instantiations of generic
+// interfaces and function types, such as Repo[example.com/models.User].
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go.uber.org/mock/mockgen/model"
+)
+
+// SplitSymbols splits a comma-separated list of symbols. Commas between
+// brackets separate the type arguments of an instantiation, such as
+// Map[string,example.com/models.User], and do not split the list.
+func SplitSymbols(s string) []string {
+	return splitTopLevel(s, ',')
+}
+
+// splitTopLevel splits s at the occurrences of sep outside of brackets.
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// closingBracket returns the index of the bracket closing the one at s[open],
+// or -1.
+func closingBracket(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// An instance is a symbol naming an instantiation of a generic type.
+type instance struct {
+	symbol string   // e.g. Repo[example.com/models.User]
+	name   string   // the generic type, e.g. Repo
+	args   []string // the type arguments, e.g. example.com/models.User
+}
+
+// parseInstance parses symbol, and reports whether it is an instantiation.
+func parseInstance(symbol string) (instance, bool, error) {
+	i := strings.Index(symbol, "[")
+	if i < 0 {
+		return instance{}, false, nil
+	}
+	if closingBracket(symbol, i) != len(symbol)-1 || !token.IsIdentifier(symbol[:i]) {
+		return instance{}, false, fmt.Errorf("bad instantiation %q, want a name such as Repo[example.com/models.User]", symbol)
+	}
+	inst := instance{symbol: symbol, name: symbol[:i]}
+	for _, arg := range splitTopLevel(symbol[i+1:len(symbol)-1], ',') {
+		inst.args = append(inst.args, strings.TrimSpace(arg))
+	}
+	return inst, true, nil
+}
+
+// loadInstances loads symbols with load, which is called with the generic
+// types in place of their instantiations, and then instantiates them.
+func loadInstances(symbols []string, load func(symbols []string) (*model.Package, error)) (*model.Package, error) {
+	var names []string
+	var insts []instance
+	generic := make(map[string]bool) // requested without instantiation
+	seen := make(map[string]bool)
+	for _, symbol := range symbols {
+		inst, ok, err := parseInstance(symbol)
+		if err != nil {
+			return nil, err
+		}
+		name := symbol
+		if ok {
+			name = inst.name
+			if seen[symbol] {
+				continue
+			}
+			seen[symbol] = true
+			insts = append(insts, inst)
+		} else {
+			generic[symbol] = true
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	if len(insts) == 0 {
+		return load(symbols)
+	}
+
+	pkg, err := load(names)
+	if err != nil {
+		return nil, err
+	}
+	var intfs []*model.Interface
+	symbolOf := make(map[string]string) // the symbol of each interface by name
+	add := func(intf *model.Interface, symbol string) error {
+		if other, ok := symbolOf[intf.Name]; ok {
+			return fmt.Errorf("%s and %s are both named %s; generate them with separate invocations of mockgen and name them apart with -mock_names", other, symbol, intf.Name)
+		}
+		symbolOf[intf.Name] = symbol
+		intfs = append(intfs, intf)
+		return nil
+	}
+	for _, intf := range pkg.Interfaces {
+		if generic[intf.Name] {
+			if err := add(intf, intf.Name); err != nil {
+				return nil, err
+			}
+		}
+		for _, inst := range insts {
+			if inst.name != intf.Name {
+				continue
+			}
+			instIntf, err := instantiate(intf, pkg.PkgPath, inst)
+			if err != nil {
+				return nil, err
+			}
+			if err := add(instIntf, inst.symbol); err != nil {
+				return nil, err
+			}
+		}
+	}
+	pkg.Interfaces = intfs
+	return pkg, nil
+}
+
+// instantiate returns the non-generic interface obtained by substituting the
+// type arguments of inst for the type parameters of intf, which is declared
+// in the package pkgPath. The type arguments are not checked against the
+// constraints of the type parameters.
+func instantiate(intf *model.Interface, pkgPath string, inst instance) (*model.Interface, error) {
+	if len(intf.TypeParams) == 0 {
+		return nil, fmt.Errorf("%s: %s is not generic", inst.symbol, intf.Name)
+	}
+	if intf.Extracted {
+		return nil, fmt.Errorf("%s: instantiations of extracted interfaces are not supported", inst.symbol)
+	}
+	if len(inst.args) != len(intf.TypeParams) {
+		return nil, fmt.Errorf("%s: %s has %d type parameters, got %d type arguments", inst.symbol, intf.Name, len(intf.TypeParams), len(inst.args))
+	}
+
+	s := substitution{pkgPath: pkgPath, types: make(map[string]model.Type)}
+	args := make([]model.Type, len(inst.args))
+	for i, arg := range inst.args {
+		t, err := parseTypeArg(arg, pkgPath)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", inst.symbol, err)
+		}
+		args[i] = t
+		s.types[intf.TypeParams[i].Name] = t
+	}
+
+	instIntf := &model.Interface{
+		Name:     instanceName(intf.Name, args),
+		FuncType: intf.FuncType,
+		Doc:      intf.Doc,
+		Pos:      intf.Pos,
+		Instance: &model.NamedType{
+			Package:    pkgPath,
+			Type:       intf.Name,
+			TypeParams: &model.TypeParametersType{TypeParameters: args},
+		},
+	}
+	for _, m := range intf.Methods {
+		instIntf.Methods = append(instIntf.Methods, &model.Method{
+			Name:     m.Name,
+			In:       s.params(m.In),
+			Out:      s.params(m.Out),
+			Variadic: s.param(m.Variadic),
+			Doc:      m.Doc,
+			Pos:      m.Pos,
+		})
+	}
+	return instIntf, nil
+}
+
+// instanceName returns the name of the instantiation of the generic type name
+// with args: the names of the type arguments followed by name, e.g. UserRepo
+// for Repo[User].
+func instanceName(name string, args []model.Type) string {
+	var sb strings.Builder
+	for _, arg := range args {
+		sb.WriteString(typeArgName(arg))
+	}
+	sb.WriteString(name)
+	return sb.String()
+}
+
+func typeArgName(t model.Type) string {
+	switch t := t.(type) {
+	case *model.NamedType:
+		return t.Type
+	case model.PredeclaredType:
+		// Keep the leading identifier, e.g. struct of struct{}.
+		name := strings.FieldsFunc(string(t), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if len(name) == 0 {
+			return ""
+		}
+		return strings.ToUpper(name[0][:1]) + name[0][1:]
+	case *model.PointerType:
+		return typeArgName(t.Type)
+	case *model.ArrayType:
+		return typeArgName(t.Type) + "Slice"
+	case *model.MapType:
+		return typeArgName(t.Key) + typeArgName(t.Value) + "Map"
+	case *model.ChanType:
+		return typeArgName(t.Type) + "Chan"
+	case *model.FuncType:
+		return "Func"
+	}
+	return ""
+}
+
+// parseTypeArg parses the type argument s. Named types are qualified by
+// their import path, e.g. example.com/models.User, or else declared in the
+// package pkgPath unless predeclared.
+func parseTypeArg(s, pkgPath string) (model.Type, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case strings.HasPrefix(s, "*"):
+		t, err := parseTypeArg(s[1:], pkgPath)
+		if err != nil {
+			return nil, err
+		}
+		return &model.PointerType{Type: t}, nil
+	case strings.HasPrefix(s, "[]"):
+		t, err := parseTypeArg(s[2:], pkgPath)
+		if err != nil {
+			return nil, err
+		}
+		return &model.ArrayType{Len: -1, Type: t}, nil
+	case strings.HasPrefix(s, "map["):
+		end := closingBracket(s, len("map"))
+		if end < 0 {
+			return nil, fmt.Errorf("bad type argument %q", s)
+		}
+		key, err := parseTypeArg(s[len("map["):end], pkgPath)
+		if err != nil {
+			return nil, err
+		}
+		value, err := parseTypeArg(s[end+1:], pkgPath)
+		if err != nil {
+			return nil, err
+		}
+		return &model.MapType{Key: key, Value: value}, nil
+	case strings.HasPrefix(s, "["):
+		end := closingBracket(s, 0)
+		if end < 0 {
+			return nil, fmt.Errorf("bad type argument %q", s)
+		}
+		n, err := strconv.Atoi(s[1:end])
+		if err != nil {
+			return nil, fmt.Errorf("bad array length in type argument %q", s)
+		}
+		t, err := parseTypeArg(s[end+1:], pkgPath)
+		if err != nil {
+			return nil, err
+		}
+		return &model.ArrayType{Len: n, Type: t}, nil
+	}
+
+	// A named type, possibly instantiated.
+	name, args := s, []string(nil)
+	if i := strings.Index(s, "["); i >= 0 {
+		if closingBracket(s, i) != len(s)-1 {
+			return nil, fmt.Errorf("bad type argument %q", s)
+		}
+		name, args = s[:i], splitTopLevel(s[i+1:len(s)-1], ',')
+	}
+	pkg, typ := "", name
+	if i := strings.LastIndex(name, "."); i >= 0 && i > strings.LastIndex(name, "/") {
+		pkg, typ = name[:i], name[i+1:]
+	}
+	if !token.IsIdentifier(typ) || (pkg == "" && strings.Contains(name, "/")) {
+		return nil, fmt.Errorf("bad type argument %q, want a type such as example.com/models.User", s)
+	}
+	if pkg == "" {
+		if _, ok := types.Universe.Lookup(typ).(*types.TypeName); ok && len(args) == 0 {
+			return model.PredeclaredType(typ), nil
+		}
+		pkg = pkgPath
+	}
+
+	nt := &model.NamedType{Package: pkg, Type: typ}
+	if len(args) > 0 {
+		nt.TypeParams = &model.TypeParametersType{}
+		for _, arg := range args {
+			t, err := parseTypeArg(arg, pkgPath)
+			if err != nil {
+				return nil, err
+			}
+			nt.TypeParams.TypeParameters = append(nt.TypeParams.TypeParameters, t)
+		}
+	}
+	return nt, nil
+}
+
+// A substitution replaces the type parameters of a generic type declared in
+// the package pkgPath by types.
+type substitution struct {
+	pkgPath string
+	types   map[string]model.Type
+}
+
+func (s substitution) params(params []*model.Parameter) []*model.Parameter {
+	if params == nil {
+		return nil
+	}
+	substituted := make([]*model.Parameter, len(params))
+	for i, p := range params {
+		substituted[i] = s.param(p)
+	}
+	return substituted
+}
+
+func (s substitution) param(p *model.Parameter) *model.Parameter {
+	if p == nil {
+		return nil
+	}
+	return &model.Parameter{Name: p.Name, Type: s.typ(p.Type), Pos: p.Pos}
+}
+
+func (s substitution) typ(t model.Type) model.Type {
+	switch t := t.(type) {
+	case model.PredeclaredType:
+		// Source mode models type parameters as predeclared types...
+		if st, ok := s.types[string(t)]; ok {
+			return st
+		}
+	case *model.NamedType:
+		// ...and package mode as named types of no package. Type
+		// parameters shadow the types of their package.
+		if t.TypeParams == nil && (t.Package == "" || t.Package == s.pkgPath) {
+			if st, ok := s.types[t.Type]; ok {
+				return st
+			}
+		}
+		return &model.NamedType{Package: t.Package, Type: t.Type, TypeParams: s.typeParams(t.TypeParams)}
+	case *model.ArrayType:
+		return &model.ArrayType{Len: t.Len, Type: s.typ(t.Type)}
+	case *model.ChanType:
+		return &model.ChanType{Dir: t.Dir, Type: s.typ(t.Type)}
+	case *model.MapType:
+		return &model.MapType{Key: s.typ(t.Key), Value: s.typ(t.Value)}
+	case *model.PointerType:
+		return &model.PointerType{Type: s.typ(t.Type)}
+	case *model.FuncType:
+		return &model.FuncType{In: s.params(t.In), Out: s.params(t.Out), Variadic: s.param(t.Variadic)}
+	}
+	return t
+}
+
+func (s substitution) typeParams(tp *model.TypeParametersType) *model.TypeParametersType {
+	if tp == nil {
+		return nil
+	}
+	substituted := &model.TypeParametersType{TypeParameters: make([]model.Type, len(tp.TypeParameters))}
+	for i, t := range tp.TypeParameters {
+		substituted.TypeParameters[i] = s.typ(t)
+	}
+	return substituted
+}
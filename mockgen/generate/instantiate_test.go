@@ -0,0 +1,163 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/mockgen/model"
+)
+
+func TestSplitSymbols(t *testing.T) {
+	assert.Equal(t, []string{"Foo", "Map[string,example.com/x.Box[int,bool]]", "Bar"}, SplitSymbols("Foo,Map[string,example.com/x.Box[int,bool]],Bar"))
+}
+
+func TestParseTypeArg(t *testing.T) {
+	const pkgPath = "example.com/repo"
+	user := &model.NamedType{Package: "example.com/models", Type: "User"}
+	tests := []struct {
+		arg  string
+		want model.Type
+	}{
+		{"string", model.PredeclaredType("string")},
+		{"error", model.PredeclaredType("error")},
+		{"example.com/models.User", user},
+		{"Local", &model.NamedType{Package: pkgPath, Type: "Local"}},
+		{"*example.com/models.User", &model.PointerType{Type: user}},
+		{"[]example.com/models.User", &model.ArrayType{Len: -1, Type: user}},
+		{"[4]byte", &model.ArrayType{Len: 4, Type: model.PredeclaredType("byte")}},
+		{"map[string]*example.com/models.User", &model.MapType{Key: model.PredeclaredType("string"), Value: &model.PointerType{Type: user}}},
+		{"example.com/x.Box[example.com/models.User, int]", &model.NamedType{
+			Package: "example.com/x",
+			Type:    "Box",
+			TypeParams: &model.TypeParametersType{
+				TypeParameters: []model.Type{user, model.PredeclaredType("int")},
+			},
+		}},
+	}
+	for _, tt := range tests {
+		got, err := parseTypeArg(tt.arg, pkgPath)
+		require.NoError(t, err, tt.arg)
+		assert.Equal(t, tt.want, got, tt.arg)
+	}
+
+	for _, arg := range []string{"", "example.com/models", "map[string", "[n]int", "Box[int", "a-b"} {
+		_, err := parseTypeArg(arg, pkgPath)
+		assert.Error(t, err, arg)
+	}
+}
+
+func TestInstantiate(t *testing.T) {
+	const pkgPath = "example.com/repo"
+	errorType := model.PredeclaredType("error")
+	repo := &model.Interface{
+		Name: "Repo",
+		TypeParams: []*model.Parameter{
+			{Name: "T", Type: &model.NamedType{Type: "any"}},
+			{Name: "Key", Type: &model.NamedType{Type: "comparable"}},
+		},
+		Methods: []*model.Method{{
+			Name: "Get",
+			// Source mode models the type parameters as predeclared types,
+			// or named types of the package for exported names.
+			In:  []*model.Parameter{{Name: "key", Type: &model.NamedType{Package: pkgPath, Type: "Key"}}},
+			Out: []*model.Parameter{{Type: model.PredeclaredType("T")}, {Type: errorType}},
+		}, {
+			Name: "Put",
+			// Package mode models them as named types of no package.
+			Variadic: &model.Parameter{Name: "items", Type: &model.MapType{
+				Key:   &model.NamedType{Type: "Key"},
+				Value: &model.NamedType{Package: "example.com/x", Type: "Box", TypeParams: &model.TypeParametersType{TypeParameters: []model.Type{&model.NamedType{Type: "T"}}}},
+			}},
+		}},
+	}
+
+	inst, ok, err := parseInstance("Repo[example.com/models.User,string]")
+	require.NoError(t, err)
+	require.True(t, ok)
+	got, err := instantiate(repo, pkgPath, inst)
+	require.NoError(t, err)
+
+	user := &model.NamedType{Package: "example.com/models", Type: "User"}
+	assert.Equal(t, &model.Interface{
+		Name: "UserStringRepo",
+		Instance: &model.NamedType{
+			Package:    pkgPath,
+			Type:       "Repo",
+			TypeParams: &model.TypeParametersType{TypeParameters: []model.Type{user, model.PredeclaredType("string")}},
+		},
+		Methods: []*model.Method{{
+			Name: "Get",
+			In:   []*model.Parameter{{Name: "key", Type: model.PredeclaredType("string")}},
+			Out:  []*model.Parameter{{Type: user}, {Type: errorType}},
+		}, {
+			Name: "Put",
+			Variadic: &model.Parameter{Name: "items", Type: &model.MapType{
+				Key:   model.PredeclaredType("string"),
+				Value: &model.NamedType{Package: "example.com/x", Type: "Box", TypeParams: &model.TypeParametersType{TypeParameters: []model.Type{user}}},
+			}},
+		}},
+	}, got)
+
+	for symbol, wantErr := range map[string]string{
+		"Repo[int]":                    "Repo[int]: Repo has 2 type parameters, got 1 type arguments",
+		"Repo[int,example.com/models]": `Repo[int,example.com/models]: bad type argument "example.com/models", want a type such as example.com/models.User`,
+	} {
+		inst, _, err := parseInstance(symbol)
+		require.NoError(t, err)
+		_, err = instantiate(repo, pkgPath, inst)
+		assert.EqualError(t, err, wantErr)
+	}
+
+	_, err = instantiate(&model.Interface{Name: "Plain"}, pkgPath, instance{symbol: "Plain[int]", name: "Plain", args: []string{"int"}})
+	assert.EqualError(t, err, "Plain[int]: Plain is not generic")
+}
+
+func TestLoadInstances(t *testing.T) {
+	repo := &model.Interface{
+		Name:       "Repo",
+		TypeParams: []*model.Parameter{{Name: "T", Type: &model.NamedType{Type: "any"}}},
+	}
+	var loaded []string
+	load := func(symbols []string) (*model.Package, error) {
+		loaded = symbols
+		return &model.Package{PkgPath: "example.com/repo", Interfaces: []*model.Interface{repo}}, nil
+	}
+
+	pkg, err := loadInstances([]string{"Repo[int]", "Repo", "Repo[string]"}, load)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Repo"}, loaded)
+	var names []string
+	for _, intf := range pkg.Interfaces {
+		names = append(names, intf.Name)
+	}
+	assert.Equal(t, []string{"Repo", "IntRepo", "StringRepo"}, names)
+
+	_, err = loadInstances([]string{"Repo[int"}, load)
+	assert.Error(t, err)
+}
+
+func TestLoadInstances_NameCollision(t *testing.T) {
+	load := func(symbols []string) (*model.Package, error) {
+		return &model.Package{PkgPath: "example.com/repo", Interfaces: []*model.Interface{{
+			Name:       "Repo",
+			TypeParams: []*model.Parameter{{Name: "T", Type: &model.NamedType{Type: "any"}}},
+		}}}, nil
+	}
+
+	pkg, err := loadInstances([]string{"Repo[User]", "Repo[User]"}, load)
+	require.NoError(t, err)
+	assert.Len(t, pkg.Interfaces, 1, "the same instantiation is generated once")
+
+	for _, symbols := range [][]string{
+		{"Repo[User]", "Repo[*User]"},
+		{"Repo[example.com/a.T]", "Repo[example.com/b.T]"},
+	} {
+		_, err := loadInstances(symbols, load)
+		if assert.Error(t, err, symbols) {
+			assert.Contains(t, err.Error(), symbols[0]+" and "+symbols[1]+" are both named")
+			assert.Contains(t, err.Error(), "-mock_names")
+		}
+	}
+}
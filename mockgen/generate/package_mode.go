@@ -1,29 +1,44 @@
-package main
+package generate
 
 import (
 	"errors"
-	"flag"
 	"fmt"
+	"go/token"
 	"go/types"
-	"strings"
 
 	"go.uber.org/mock/mockgen/model"
 	"golang.org/x/tools/go/packages"
 )
 
-var (
-	buildFlags = flag.String("build_flags", "", "(package mode) Additional flags for go build.")
-)
-
-type packageModeParser struct{}
+type packageModeParser struct {
+	opts LoadOptions
+}
 
 func (p *packageModeParser) parsePackage(packageName string, ifaces []string) (*model.Package, error) {
+	if p.opts.Hermetic {
+		return p.parseSourcePackage(packageName, ifaces)
+	}
+
+	exportFile, err := p.exportFile(packageName)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.parseExportFile(packageName, ifaces, exportFile)
+}
+
+// exportFile loads the package and returns the path to its export data.
+func (p *packageModeParser) exportFile(packageName string) (string, error) {
 	pkg, err := p.loadPackage(packageName)
 	if err != nil {
-		return nil, fmt.Errorf("load package: %w", err)
+		return "", fmt.Errorf("load package: %w", err)
 	}
 
-	modelPackage, err := parseExportFile(packageName, ifaces, pkg.ExportFile)
+	return pkg.ExportFile, nil
+}
+
+func (p *packageModeParser) parseExportFile(packageName string, ifaces []string, exportFile string) (*model.Package, error) {
+	modelPackage, err := parseExportFile(packageName, ifaces, exportFile, p.opts)
 	if err != nil {
 		return nil, fmt.Errorf("extract interfaces from package: %w", err)
 	}
@@ -32,14 +47,9 @@ func (p *packageModeParser) parsePackage(packageName string, ifaces []string) (*
 }
 
 func (p *packageModeParser) loadPackage(packageName string) (*packages.Package, error) {
-	var buildFlagsSet []string
-	if *buildFlags != "" {
-		buildFlagsSet = strings.Split(*buildFlags, " ")
-	}
-
 	cfg := &packages.Config{
 		Mode:       packages.NeedExportFile,
-		BuildFlags: buildFlagsSet,
+		BuildFlags: p.opts.BuildFlags,
 	}
 	pkgs, err := packages.Load(cfg, packageName)
 	if err != nil {
@@ -62,35 +72,50 @@ func (p *packageModeParser) loadPackage(packageName string) (*packages.Package,
 	return pkgs[0], nil
 }
 
-func extractInterfacesFromPackageTypes(pkgTypes *types.Package, ifaces []string) ([]*model.Interface, error) {
+// extractInterfacesFromPackageTypes returns the named interfaces of pkgTypes.
+// Source positions are resolved in fset. If opts.ExtractInterfaces is set,
+// interfaces are extracted from the named concrete types. The errors of all
+// the interfaces that cannot be parsed are reported together.
+func extractInterfacesFromPackageTypes(fset *token.FileSet, pkgTypes *types.Package, ifaces []string, opts LoadOptions) ([]*model.Interface, error) {
 	// If no interfaces specified, discover all interfaces in the package
-	if len(ifaces) == 0  {
-		return getAllInterfacesFromPackageTypes(pkgTypes)
+	if len(ifaces) == 0 {
+		return getAllInterfacesFromPackageTypes(fset, pkgTypes, opts)
 	}
 	scope := pkgTypes.Scope()
-	interfaces := make([]*model.Interface, len(ifaces))
-	for i, iface := range ifaces {
+	var interfaces []*model.Interface
+	var errs []error
+	for _, iface := range ifaces {
 		obj := scope.Lookup(iface)
 		if obj == nil {
 			return nil, fmt.Errorf("interface %s does not exist", iface)
 		}
 
-		modelIface, err := parseInterface(obj)
+		modelIface, err := parseInterface(fset, obj, opts.ExtractInterfaces)
 		if err != nil {
-			return nil, newParseTypeError("parse interface", obj.Name(), err)
+			if !skipUnsupported(opts.SkipUnsupported, obj.Name(), err) {
+				errs = append(errs, newParseTypeError("parse interface", obj.Name(), err))
+			}
+			continue
 		}
 
-		interfaces[i] = modelIface
+		interfaces = append(interfaces, modelIface)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	if len(interfaces) == 0 {
+		return nil, fmt.Errorf("no supported interfaces found in package %s", pkgTypes.Path())
 	}
 
 	return interfaces, nil
 }
 
 // getAllInterfacesFromPackageTypes discovers and returns all exported interfaces in the package.
-func getAllInterfacesFromPackageTypes(pkgTypes *types.Package) ([]*model.Interface, error) {
+func getAllInterfacesFromPackageTypes(fset *token.FileSet, pkgTypes *types.Package, opts LoadOptions) ([]*model.Interface, error) {
 	scope := pkgTypes.Scope()
 	names := scope.Names()
 	var interfaces []*model.Interface
+	var errs []error
 	for _, name := range names {
 		obj := scope.Lookup(name)
 		if obj == nil {
@@ -113,56 +138,181 @@ func getAllInterfacesFromPackageTypes(pkgTypes *types.Package) ([]*model.Interfa
 		if isConstraint(iface) {
 			continue
 		}
-		modelIface, err := parseInterface(obj)
+		modelIface, err := parseInterface(fset, obj, false)
 		if err != nil {
-			return nil, newParseTypeError("parse interface", obj.Name(), err)
+			if !skipUnsupported(opts.SkipUnsupported, obj.Name(), err) {
+				errs = append(errs, newParseTypeError("parse interface", obj.Name(), err))
+			}
+			continue
 		}
 		interfaces = append(interfaces, modelIface)
 	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
 	if len(interfaces) == 0 {
 		return nil, fmt.Errorf("no interfaces found in package %s", pkgTypes.Path())
 	}
 	return interfaces, nil
 }
 
-func parseInterface(obj types.Object) (*model.Interface, error) {
+func parseInterface(fset *token.FileSet, obj types.Object, extract bool) (*model.Interface, error) {
 	named, ok := types.Unalias(obj.Type()).(*types.Named)
 	if !ok {
 		return nil, fmt.Errorf("%s is not an interface. it is a %T", obj.Name(), obj.Type().Underlying())
 	}
 
+	if sig, ok := named.Underlying().(*types.Signature); ok {
+		return parseFuncTypeInterface(fset, obj, named, sig)
+	}
+
 	iface, ok := named.Underlying().(*types.Interface)
 	if !ok {
+		if extract {
+			return parseConcreteType(fset, obj, named)
+		}
 		return nil, fmt.Errorf("%s is not an interface. it is a %T", obj.Name(), obj.Type().Underlying())
 	}
 
 	if isConstraint(iface) {
-		return nil, fmt.Errorf("interface %s is a constraint", obj.Name())
+		return nil, unsupported(fmt.Errorf("interface %s is a constraint", obj.Name()))
 	}
 
+	// Report all of the methods that cannot be parsed.
+	var errs []error
 	methods := make([]*model.Method, iface.NumMethods())
 	for i := range iface.NumMethods() {
-		method := iface.Method(i)
-		typedMethod, ok := method.Type().(*types.Signature)
-		if !ok {
-			return nil, fmt.Errorf("method %s is not a signature", method.Name())
+		method, err := parseMethod(fset, iface.Method(i))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("method %s: %w", iface.Method(i).Name(), err))
+			continue
 		}
+		methods[i] = method
+	}
 
-		modelFunc, err := parseFunc(typedMethod)
+	typeParams, err := parseTypeParams(named)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if err := unsupported(errs...); err != nil {
+		return nil, err
+	}
+
+	return &model.Interface{
+		Name:       obj.Name(),
+		Methods:    methods,
+		TypeParams: typeParams,
+		Pos:        position(fset, obj.Pos()),
+	}, nil
+}
+
+// parseConcreteType returns the interface extracted from the exported method
+// set of a pointer to the concrete type named, which includes the methods
+// with value receivers and the promoted methods.
+func parseConcreteType(fset *token.FileSet, obj types.Object, named *types.Named) (*model.Interface, error) {
+	mset := types.NewMethodSet(types.NewPointer(named))
+	var methods []*model.Method
+	var errs []error
+	for i := range mset.Len() {
+		method, ok := mset.At(i).Obj().(*types.Func)
+		if !ok || !method.Exported() {
+			continue
+		}
+		modelMethod, err := parseMethod(fset, method)
 		if err != nil {
-			return nil, newParseTypeError("parse method", typedMethod.String(), err)
+			errs = append(errs, fmt.Errorf("method %s: %w", method.Name(), err))
+			continue
 		}
+		methods = append(methods, modelMethod)
+	}
+	if len(methods) == 0 && len(errs) == 0 {
+		return nil, fmt.Errorf("%s has no exported methods", obj.Name())
+	}
 
-		methods[i] = &model.Method{
-			Name:     method.Name(),
-			In:       modelFunc.In,
-			Out:      modelFunc.Out,
-			Variadic: modelFunc.Variadic,
-		}
+	typeParams, err := parseTypeParams(named)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if err := unsupported(errs...); err != nil {
+		return nil, err
+	}
+
+	return &model.Interface{
+		Name:       obj.Name(),
+		Methods:    methods,
+		TypeParams: typeParams,
+		Extracted:  true,
+		Pos:        position(fset, obj.Pos()),
+	}, nil
+}
+
+func parseMethod(fset *token.FileSet, method *types.Func) (*model.Method, error) {
+	typedMethod, ok := method.Type().(*types.Signature)
+	if !ok {
+		return nil, fmt.Errorf("method %s is not a signature", method.Name())
+	}
+
+	modelFunc, err := parseFunc(typedMethod)
+	if err != nil {
+		return nil, newParseTypeError("parse method", typedMethod.String(), err)
 	}
+	setParamPositions(fset, modelFunc, typedMethod)
+
+	return &model.Method{
+		Name:     method.Name(),
+		In:       modelFunc.In,
+		Out:      modelFunc.Out,
+		Variadic: modelFunc.Variadic,
+		Pos:      position(fset, method.Pos()),
+	}, nil
+}
 
+// parseFuncTypeInterface returns the interface synthesized from a named
+// function type, so that it can be mocked like any other interface.
+func parseFuncTypeInterface(fset *token.FileSet, obj types.Object, named *types.Named, sig *types.Signature) (*model.Interface, error) {
+	modelFunc, err := parseFunc(sig)
+	if err != nil {
+		return nil, unsupported(newParseTypeError("parse func type", sig.String(), err))
+	}
+	setParamPositions(fset, modelFunc, sig)
+
+	typeParams, err := parseTypeParams(named)
+	if err != nil {
+		return nil, unsupported(err)
+	}
+
+	intf := model.NewFuncTypeInterface(obj.Name(), modelFunc, typeParams)
+	intf.Pos = position(fset, obj.Pos())
+	return intf, nil
+}
+
+// setParamPositions records the source positions of the parameters and
+// results of sig in ft, which must have been parsed from sig.
+func setParamPositions(fset *token.FileSet, ft *model.FuncType, sig *types.Signature) {
+	params := ft.In
+	if ft.Variadic != nil {
+		params = append(params[:len(params):len(params)], ft.Variadic)
+	}
+	for i, p := range params {
+		p.Pos = position(fset, sig.Params().At(i).Pos())
+	}
+	for i, p := range ft.Out {
+		p.Pos = position(fset, sig.Results().At(i).Pos())
+	}
+}
+
+// position returns the position of pos in fset, or the zero Position if pos
+// is unknown.
+func position(fset *token.FileSet, pos token.Pos) token.Position {
+	if fset == nil || !pos.IsValid() {
+		return token.Position{}
+	}
+	return fset.Position(pos)
+}
+
+func parseTypeParams(named *types.Named) ([]*model.Parameter, error) {
 	if named.TypeParams() == nil {
-		return &model.Interface{Name: obj.Name(), Methods: methods}, nil
+		return nil, nil
 	}
 
 	typeParams := make([]*model.Parameter, named.TypeParams().Len())
@@ -176,7 +326,7 @@ func parseInterface(obj types.Object) (*model.Interface, error) {
 		typeParams[i] = &model.Parameter{Name: param.Obj().Name(), Type: typeParam}
 	}
 
-	return &model.Interface{Name: obj.Name(), Methods: methods, TypeParams: typeParams}, nil
+	return typeParams, nil
 }
 
 func isConstraint(t *types.Interface) bool {
@@ -292,12 +442,12 @@ func parseType(t types.Type) (model.Type, error) {
 		return model.PredeclaredType("struct{}"), nil
 	case *types.Basic:
 		return model.PredeclaredType(t.Name()), nil
-	case *types.Tuple:
-		panic("tuple field") // TODO
 	case *types.TypeParam:
 		return &model.NamedType{Type: t.Obj().Name()}, nil
+	case *types.Union:
+		return parseUnion(t)
 	default:
-		panic("unknown type") // TODO
+		return nil, fmt.Errorf("cannot handle %T %s", t, t)
 	}
 }
 
@@ -364,6 +514,18 @@ func parseConstraint(t *types.TypeParam) (model.Type, error) {
 		return nil, fmt.Errorf("nil type param")
 	}
 
+	// Type sets such as [T ~int | ~float64] are implicit interfaces
+	// embedding a union. They are modeled as the union.
+	if iface, ok := t.Constraint().(*types.Interface); ok && iface.NumExplicitMethods() == 0 && iface.NumEmbeddeds() == 1 {
+		if embedded := iface.EmbeddedType(0); iface.IsImplicit() || isUnion(embedded) {
+			typeParam, err := parseType(embedded)
+			if err != nil {
+				return nil, newParseTypeError("parse constraint type", t.Constraint().String(), err)
+			}
+			return typeParam, nil
+		}
+	}
+
 	typeParam, err := parseType(t.Constraint())
 	if err != nil {
 		return nil, newParseTypeError("parse constraint type", t.Constraint().String(), err)
@@ -372,6 +534,25 @@ func parseConstraint(t *types.TypeParam) (model.Type, error) {
 	return typeParam, nil
 }
 
+func isUnion(t types.Type) bool {
+	_, ok := t.(*types.Union)
+	return ok
+}
+
+// parseUnion returns the model of the type set u.
+func parseUnion(u *types.Union) (*model.UnionType, error) {
+	union := &model.UnionType{Terms: make([]*model.UnionTerm, u.Len())}
+	for i := range u.Len() {
+		term := u.Term(i)
+		termType, err := parseType(term.Type())
+		if err != nil {
+			return nil, newParseTypeError("parse union term", term.String(), err)
+		}
+		union.Terms[i] = &model.UnionTerm{Tilde: term.Tilde(), Type: termType}
+	}
+	return union, nil
+}
+
 type parseTypeError struct {
 	message    string
 	typeString string
@@ -1,6 +1,8 @@
-package main
+package generate
 
 import (
+	"go/token"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -79,6 +81,48 @@ func Test_packageModeParser_parsePackage(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "success: func type",
+			args: args{
+				packageName: "go.uber.org/mock/mockgen/internal/tests/package_mode",
+				ifaces:      []string{"Feeder"},
+			},
+			expected: &model.Package{
+				Name:    "package_mode",
+				PkgPath: "go.uber.org/mock/mockgen/internal/tests/package_mode",
+				Interfaces: []*model.Interface{
+					{
+						Name: "Feeder",
+						Methods: []*model.Method{
+							{
+								Name: "Call",
+								In: []*model.Parameter{
+									{
+										Name: "eater",
+										Type: &model.NamedType{
+											Package: "go.uber.org/mock/mockgen/internal/tests/package_mode",
+											Type:    "Eater",
+										},
+									},
+								},
+								Out: []*model.Parameter{
+									{Type: model.PredeclaredType("int")},
+									{Type: &model.NamedType{Type: "error"}},
+								},
+								Variadic: &model.Parameter{
+									Name: "foods",
+									Type: &model.NamedType{
+										Package: "go.uber.org/mock/mockgen/internal/tests/package_mode",
+										Type:    "Food",
+									},
+								},
+							},
+						},
+						FuncType: true,
+					},
+				},
+			},
+		},
 		{
 			name: "success: interface with variadic args",
 			args: args{
@@ -367,6 +411,7 @@ func Test_packageModeParser_parsePackage(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 			}
+			clearPositions(actual)
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
@@ -550,7 +595,131 @@ func TestAliases(t *testing.T) {
 			require.Len(t, actual.Interfaces, 1)
 			assert.Equal(t, "alias", actual.Name)
 			assert.Equal(t, "go.uber.org/mock/mockgen/internal/tests/alias", actual.PkgPath)
+			clearPositions(actual)
 			assert.Equal(t, tt.expected, actual.Interfaces[0])
 		})
 	}
 }
+
+func TestPackageModePositions(t *testing.T) {
+	var parser packageModeParser
+	pkg, err := parser.parsePackage("go.uber.org/mock/mockgen/internal/tests/doc_comments", []string{"Store"})
+	require.NoError(t, err)
+	require.Len(t, pkg.Interfaces, 1)
+
+	intf := pkg.Interfaces[0]
+	assert.Equal(t, "input.go", filepath.Base(intf.Pos.Filename))
+	assert.Equal(t, 11, intf.Pos.Line)
+	assert.Empty(t, intf.Doc, "export data has no comments")
+
+	var get *model.Method
+	for _, m := range intf.Methods {
+		if m.Name == "Get" {
+			get = m
+		}
+	}
+	require.NotNil(t, get)
+	assert.Equal(t, 15, get.Pos.Line)
+	require.Len(t, get.In, 1)
+	assert.Equal(t, "key", get.In[0].Name)
+	assert.Equal(t, 15, get.In[0].Pos.Line)
+
+	loadDocComments(pkg)
+	assert.Equal(t, "Store persists blobs.\n\nImplementations must be safe for concurrent use.\n", intf.Doc)
+	assert.Equal(t, "Get returns the blob stored under key.\n", get.Doc)
+}
+
+func TestPackageModeExtractInterfaces(t *testing.T) {
+	ctx := &model.NamedType{Package: "context", Type: "Context"}
+	errorType := &model.NamedType{Type: "error"}
+	parser := packageModeParser{opts: LoadOptions{ExtractInterfaces: true}}
+	pkg, err := parser.parsePackage("go.uber.org/mock/mockgen/internal/tests/extract_interface", []string{"DB"})
+	require.NoError(t, err)
+	clearPositions(pkg)
+	assert.Equal(t, []*model.Interface{{
+		Name:      "DB",
+		Extracted: true,
+		Methods: []*model.Method{
+			{Name: "Close"},
+			{
+				Name:     "Exec",
+				In:       []*model.Parameter{{Name: "ctx", Type: ctx}, {Name: "stmt", Type: model.PredeclaredType("string")}},
+				Variadic: &model.Parameter{Name: "args", Type: &model.NamedType{Type: "any"}},
+				Out:      []*model.Parameter{{Type: model.PredeclaredType("int64")}, {Type: errorType}},
+			},
+			{
+				Name: "Ping",
+				In:   []*model.Parameter{{Name: "ctx", Type: ctx}},
+				Out:  []*model.Parameter{{Type: errorType}},
+			},
+			{
+				Name: "Query",
+				In: []*model.Parameter{
+					{Name: "ctx", Type: ctx},
+					{Name: "table", Type: model.PredeclaredType("string")},
+					{Name: "limit", Type: model.PredeclaredType("int")},
+				},
+				Out: []*model.Parameter{
+					{Type: &model.ArrayType{Len: -1, Type: model.PredeclaredType("string")}},
+					{Type: errorType},
+				},
+			},
+		},
+	}}, pkg.Interfaces)
+
+	_, err = parser.parsePackage("go.uber.org/mock/mockgen/internal/tests/package_mode", []string{"Work"})
+	assert.ErrorContains(t, err, "Work has no exported methods")
+}
+
+func TestPackageModeUnsupported(t *testing.T) {
+	const packageName = "go.uber.org/mock/mockgen/internal/tests/unsupported"
+
+	var parser packageModeParser
+	_, err := parser.parsePackage(packageName, []string{"Visitor", "Number", "Store"})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "error parsing Visitor: method Visit: ")
+	assert.ErrorContains(t, err, "error parsing Number: interface Number is a constraint")
+
+	parser = packageModeParser{opts: LoadOptions{SkipUnsupported: true}}
+	pkg, err := parser.parsePackage(packageName, []string{"Visitor", "Number", "Sum", "Store"})
+	require.NoError(t, err)
+	clearPositions(pkg)
+	require.Len(t, pkg.Interfaces, 2)
+	assert.Equal(t, "Sum", pkg.Interfaces[0].Name)
+	assert.Equal(t, []*model.Parameter{{
+		Name: "T",
+		Type: &model.UnionType{Terms: []*model.UnionTerm{
+			{Tilde: true, Type: model.PredeclaredType("int")},
+			{Tilde: true, Type: model.PredeclaredType("float64")},
+		}},
+	}}, pkg.Interfaces[0].TypeParams)
+	assert.Equal(t, "Store", pkg.Interfaces[1].Name)
+
+	_, err = parser.parsePackage(packageName, []string{"Visitor"})
+	assert.ErrorContains(t, err, "no supported interfaces found")
+}
+
+// clearPositions resets the source positions recorded in pkg, so that it can
+// be compared with a model written by hand.
+func clearPositions(pkg *model.Package) {
+	if pkg == nil {
+		return
+	}
+	clearParams := func(params ...*model.Parameter) {
+		for _, p := range params {
+			if p != nil {
+				p.Pos = token.Position{}
+			}
+		}
+	}
+	for _, intf := range pkg.Interfaces {
+		intf.Pos = token.Position{}
+		clearParams(intf.TypeParams...)
+		for _, m := range intf.Methods {
+			m.Pos = token.Position{}
+			clearParams(m.In...)
+			clearParams(m.Out...)
+			clearParams(m.Variadic)
+		}
+	}
+}
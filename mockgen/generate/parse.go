@@ -12,13 +12,12 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package main
+package generate
 
 // This file contains the model construction by parsing source files.
 
 import (
 	"errors"
-	"flag"
 	"fmt"
 	"go/ast"
 	"go/build"
@@ -26,21 +25,27 @@ import (
 	"go/parser"
 	"go/token"
 	"go/types"
-	"log"
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
 	"go.uber.org/mock/mockgen/model"
 )
 
-// sourceMode generates mocks via source file.
-func sourceMode(source string) (*model.Package, error) {
-	srcDir, err := filepath.Abs(filepath.Dir(source))
+// sourceMode parses the interfaces of source, which is either a source file,
+// or the directory or import path of a package. The non-test files of a
+// package are parsed together, so that interfaces embedded from sibling files
+// are resolved without -aux_files; the package names in each interface are
+// resolved against the imports of its own file. Files are selected like go
+// build does for the build flags and the target platform.
+func sourceMode(source string, symbols []string, opts LoadOptions) (*model.Package, error) {
+	ctxt := buildContext(opts.BuildFlags)
+	srcDir, filenames, err := sourceFiles(ctxt, source)
 	if err != nil {
-		return nil, fmt.Errorf("failed getting source directory: %v", err)
+		return nil, err
 	}
 
 	packageImport, err := parsePackageImport(srcDir)
@@ -49,9 +54,13 @@ func sourceMode(source string) (*model.Package, error) {
 	}
 
 	fs := token.NewFileSet()
-	file, err := parser.ParseFile(fs, source, nil, 0)
-	if err != nil {
-		return nil, fmt.Errorf("failed parsing source file %v: %v", source, err)
+	files := make([]*ast.File, 0, len(filenames))
+	for _, filename := range filenames {
+		f, err := parser.ParseFile(fs, filename, nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing source file %v: %v", filename, err)
+		}
+		files = append(files, f)
 	}
 
 	p := &fileParser{
@@ -60,55 +69,101 @@ func sourceMode(source string) (*model.Package, error) {
 		importedInterfaces: newInterfaceCache(),
 		auxInterfaces:      newInterfaceCache(),
 		srcDir:             srcDir,
+		buildContext:       ctxt,
+		skipUnsupported:    opts.SkipUnsupported,
 	}
 
-	// positional interface names -> include set
-	if flag.NArg() > 1 {
-		return nil, errors.New("-source mode accepts at most one argument")
-	}
-	if flag.NArg() == 1 {
-		ifaces := strings.Split(flag.Arg(0), ",")
-		p.includeNamesSet = make(map[string]struct{}, len(ifaces))
-		for _, name := range ifaces {
+	// interface names -> include set
+	if len(symbols) > 0 {
+		p.includeNamesSet = make(map[string]struct{}, len(symbols))
+		for _, name := range symbols {
 			p.includeNamesSet[name] = struct{}{}
 		}
 	}
 
-	// Handle -imports.
-	dotImports := make(map[string]bool)
-	if *imports != "" {
-		for _, kv := range strings.Split(*imports, ",") {
-			eq := strings.Index(kv, "=")
-			k, v := kv[:eq], kv[eq+1:]
-			if k == "." {
-				dotImports[v] = true
-			} else {
-				p.imports[k] = importedPkg{path: v}
-			}
-		}
+	for name, path := range opts.Imports {
+		p.imports[name] = importedPkg{path: path}
 	}
 
-	if *excludeInterfaces != "" {
-		p.excludeNamesSet = parseExcludeInterfaces(*excludeInterfaces)
+	if len(opts.ExcludeInterfaces) > 0 {
+		p.excludeNamesSet = make(map[string]struct{}, len(opts.ExcludeInterfaces))
+		for _, name := range opts.ExcludeInterfaces {
+			p.excludeNamesSet[name] = struct{}{}
+		}
 	}
 
-	// Handle -aux_files.
-	if err := p.parseAuxFiles(*auxFiles); err != nil {
+	if err := p.parseAuxFiles(opts.AuxFiles); err != nil {
 		return nil, err
 	}
-	p.addAuxInterfacesFromFile(packageImport, file) // this file
+	for _, file := range files { // this file or package
+		p.addAuxInterfacesFromFile(packageImport, file)
+	}
 
-	pkg, err := p.parseFile(packageImport, file)
+	pkg, err := p.parseFile(packageImport, files...)
 	if err != nil {
 		return nil, err
 	}
-	for pkgPath := range dotImports {
-		pkg.DotImports = append(pkg.DotImports, pkgPath)
-	}
+	pkg.DotImports = append(pkg.DotImports, opts.DotImports...)
 
 	return pkg, nil
 }
 
+// buildContext returns the go/build context selecting the files that go build
+// selects with buildFlags. Only -tags is relevant; GOOS and GOARCH are taken
+// from the environment by build.Default.
+func buildContext(buildFlags []string) *build.Context {
+	ctxt := build.Default
+	for i, f := range buildFlags {
+		if !strings.HasPrefix(f, "-") {
+			continue
+		}
+		name, value, ok := strings.Cut(strings.TrimLeft(f, "-"), "=")
+		if name != "tags" {
+			continue
+		}
+		if !ok && i+1 < len(buildFlags) {
+			value = buildFlags[i+1]
+		}
+		// Tags were space-separated before Go 1.13.
+		ctxt.BuildTags = append(ctxt.BuildTags, strings.FieldsFunc(value, func(r rune) bool {
+			return r == ',' || r == ' '
+		})...)
+	}
+	return &ctxt
+}
+
+// sourceFiles returns the absolute directory and the files to parse for the
+// -source argument source.
+func sourceFiles(ctxt *build.Context, source string) (string, []string, error) {
+	fi, err := os.Stat(source)
+	if err == nil && !fi.IsDir() {
+		srcDir, err := filepath.Abs(filepath.Dir(source))
+		if err != nil {
+			return "", nil, fmt.Errorf("failed getting source directory: %v", err)
+		}
+		return srcDir, []string{source}, nil
+	}
+
+	var bp *build.Package
+	if err == nil {
+		bp, err = ctxt.ImportDir(source, 0)
+	} else {
+		bp, err = ctxt.Import(source, ".", 0)
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed loading source package %v: %v", source, err)
+	}
+	srcDir, err := filepath.Abs(bp.Dir)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed getting source directory: %v", err)
+	}
+	var filenames []string
+	for _, name := range append(bp.GoFiles, bp.CgoFiles...) {
+		filenames = append(filenames, filepath.Join(bp.Dir, name))
+	}
+	return srcDir, filenames, nil
+}
+
 type importedPackage interface {
 	Path() string
 	Parser() *fileParser
@@ -136,8 +191,10 @@ func (d duplicateImport) Error() string {
 	return fmt.Sprintf("%q is ambiguous because of duplicate imports: %v", d.name, d.duplicates)
 }
 
-func (d duplicateImport) Path() string        { log.Fatal(d.Error()); return "" }
-func (d duplicateImport) Parser() *fileParser { log.Fatal(d.Error()); return nil }
+// Path and Parser are never called: fileParser.lookupImport reports a
+// duplicateImport as an error instead of returning it.
+func (d duplicateImport) Path() string        { return "" }
+func (d duplicateImport) Parser() *fileParser { return nil }
 
 type interfaceCache struct {
 	m map[string]map[string]*namedInterface
@@ -177,12 +234,15 @@ func (i *interfaceCache) GetASTIface(pkg, name string) *ast.InterfaceType {
 type fileParser struct {
 	fileSet            *token.FileSet
 	imports            map[string]importedPackage // package name => imported package
+	fileImports        map[*ast.File]map[string]importedPackage
 	importedInterfaces *interfaceCache
 	auxFiles           []*ast.File
 	auxInterfaces      *interfaceCache
 	srcDir             string
+	buildContext       *build.Context // nil for build.Default
 	excludeNamesSet    map[string]struct{}
 	includeNamesSet    map[string]struct{} // empty to include all
+	skipUnsupported    bool
 }
 
 func (p *fileParser) errorf(pos token.Pos, format string, args ...any) error {
@@ -192,24 +252,39 @@ func (p *fileParser) errorf(pos token.Pos, format string, args ...any) error {
 	return fmt.Errorf(format, args...)
 }
 
-func (p *fileParser) parseAuxFiles(auxFiles string) error {
-	auxFiles = strings.TrimSpace(auxFiles)
-	if auxFiles == "" {
-		return nil
+// lookupImport returns the package imported under name, if any. It fails
+// if name is ambiguous because of duplicate imports.
+func (p *fileParser) lookupImport(pos token.Pos, name string) (importedPackage, bool, error) {
+	pkg, ok := p.imports[name]
+	if dup, isDup := pkg.(duplicateImport); isDup {
+		return nil, false, p.errorf(pos, "%v", dup)
 	}
-	for _, kv := range strings.Split(auxFiles, ",") {
-		parts := strings.SplitN(kv, "=", 2)
-		if len(parts) != 2 {
-			return fmt.Errorf("bad aux file spec: %v", kv)
-		}
-		pkg, fpath := parts[0], parts[1]
+	return pkg, ok, nil
+}
+
+func (p *fileParser) context() *build.Context {
+	if p.buildContext == nil {
+		return &build.Default
+	}
+	return p.buildContext
+}
 
-		file, err := parser.ParseFile(p.fileSet, fpath, nil, 0)
+func (p *fileParser) parseAuxFiles(auxFiles []AuxFile) error {
+	for _, aux := range auxFiles {
+		// Skip the files excluded by build constraints or file name suffixes.
+		match, err := p.context().MatchFile(filepath.Dir(aux.Path), filepath.Base(aux.Path))
+		if err != nil {
+			return err
+		}
+		if !match {
+			continue
+		}
+		file, err := parser.ParseFile(p.fileSet, aux.Path, nil, parser.ParseComments)
 		if err != nil {
 			return err
 		}
 		p.auxFiles = append(p.auxFiles, file)
-		p.addAuxInterfacesFromFile(pkg, file)
+		p.addAuxInterfacesFromFile(aux.Package, file)
 	}
 	return nil
 }
@@ -220,29 +295,70 @@ func (p *fileParser) addAuxInterfacesFromFile(pkg string, file *ast.File) {
 	}
 }
 
-// parseFile loads all file imports and auxiliary files import into the
-// fileParser, parses all file interfaces and returns package model.
-func (p *fileParser) parseFile(importPath string, file *ast.File) (*model.Package, error) {
-	allImports, dotImports := importsOfFile(file)
-	// Don't stomp imports provided by -imports. Those should take precedence.
-	for pkg, pkgI := range allImports {
-		if _, ok := p.imports[pkg]; !ok {
-			p.imports[pkg] = pkgI
-		}
+// addFileImports records the imports of each of files for parsing the
+// declarations of that file, and returns the dot imports of all of them.
+func (p *fileParser) addFileImports(files []*ast.File) []string {
+	if p.fileImports == nil {
+		p.fileImports = make(map[*ast.File]map[string]importedPackage)
 	}
-	// Add imports from auxiliary files, which might be needed for embedded interfaces.
-	// Don't stomp any other imports.
-	for _, f := range p.auxFiles {
-		auxImports, _ := importsOfFile(f)
-		for pkg, pkgI := range auxImports {
-			if _, ok := p.imports[pkg]; !ok {
-				p.imports[pkg] = pkgI
+	var dotImports []string
+	for _, file := range files {
+		imports := make(map[string]importedPackage)
+		// Don't stomp imports provided by -imports. Those should take precedence.
+		for pkg, pkgI := range p.imports {
+			imports[pkg] = pkgI
+		}
+		fileImports, fileDotImports := importsOfFile(file)
+		for pkg, pkgI := range fileImports {
+			if _, ok := imports[pkg]; !ok {
+				imports[pkg] = pkgI
+			}
+		}
+		// Add imports from auxiliary files, which might be needed for embedded interfaces.
+		// Don't stomp any other imports.
+		for _, f := range p.auxFiles {
+			auxImports, _ := importsOfFile(f)
+			for pkg, pkgI := range auxImports {
+				if _, ok := imports[pkg]; !ok {
+					imports[pkg] = pkgI
+				}
+			}
+		}
+		p.fileImports[file] = imports
+		for _, path := range fileDotImports {
+			if !slices.Contains(dotImports, path) {
+				dotImports = append(dotImports, path)
 			}
 		}
 	}
+	return dotImports
+}
+
+// useFileImports makes the imports of file the ones package names are
+// resolved against, and returns a function restoring the previous ones.
+// The imports are left alone for files that are not part of the parsed
+// package, such as auxiliary files.
+func (p *fileParser) useFileImports(file *ast.File) (restore func()) {
+	imports, ok := p.fileImports[file]
+	if !ok {
+		return func() {}
+	}
+	saved := p.imports
+	p.imports = imports
+	return func() { p.imports = saved }
+}
 
+// parseFile loads the imports of files, which make up a package, and of the
+// auxiliary files into the fileParser, parses the interfaces of all files and
+// returns package model.
+func (p *fileParser) parseFile(importPath string, files ...*ast.File) (*model.Package, error) {
+	dotImports := p.addFileImports(files)
+
+	// The errors of all the interfaces that cannot be parsed are reported
+	// together.
 	var is []*model.Interface
-	for ni := range iterInterfaces(file) {
+	var errs []error
+	for ni := range iterPackageInterfaces(files) {
 		name := ni.name.String()
 
 		if _, ok := p.excludeNamesSet[name]; ok {
@@ -258,18 +374,45 @@ func (p *fileParser) parseFile(importPath string, file *ast.File) (*model.Packag
 
 		i, err := p.parseInterface(name, importPath, ni)
 		if errors.Is(err, errConstraintInterface) {
-			continue
+			// Constraints are only reported when requested by name.
+			if len(p.includeNamesSet) == 0 {
+				continue
+			}
+			err = unsupported(fmt.Errorf("interface %s is a constraint", name))
 		}
+		delete(p.includeNamesSet, name)
 		if err != nil {
-			return nil, err
+			if !skipUnsupported(p.skipUnsupported, name, err) {
+				errs = append(errs, fmt.Errorf("interface %s: %w", name, err))
+			}
+			continue
 		}
 		is = append(is, i)
+	}
 
+	// Function types are only mocked when requested by name.
+	for nf := range iterPackageFuncTypes(files) {
+		name := nf.name.String()
+		if _, ok := p.includeNamesSet[name]; !ok {
+			continue
+		}
+
+		i, err := p.parseFuncType(name, importPath, nf)
 		delete(p.includeNamesSet, name)
+		if err != nil {
+			if !skipUnsupported(p.skipUnsupported, name, err) {
+				errs = append(errs, fmt.Errorf("func type %s: %w", name, err))
+			}
+			continue
+		}
+		is = append(is, i)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
 	}
 
 	return &model.Package{
-		Name:       file.Name.String(),
+		Name:       files[0].Name.String(),
 		PkgPath:    importPath,
 		Interfaces: is,
 		DotImports: dotImports,
@@ -285,25 +428,26 @@ func (p *fileParser) parsePackage(path string) (*fileParser, error) {
 		importedInterfaces: newInterfaceCache(),
 		auxInterfaces:      newInterfaceCache(),
 		srcDir:             p.srcDir,
+		buildContext:       p.buildContext,
 	}
 
-	var pkgs map[string]*ast.Package
-	if imp, err := build.Import(path, newP.srcDir, build.FindOnly); err != nil {
-		return nil, err
-	} else if pkgs, err = parser.ParseDir(newP.fileSet, imp.Dir, nil, 0); err != nil {
+	imp, err := newP.context().Import(path, newP.srcDir, 0)
+	if err != nil {
 		return nil, err
 	}
-
-	for _, pkg := range pkgs {
-		file := ast.MergePackageFiles(pkg, ast.FilterFuncDuplicates|ast.FilterUnassociatedComments|ast.FilterImportDuplicates)
-		for ni := range iterInterfaces(file) {
-			newP.importedInterfaces.Set(path, ni.name.Name, ni)
-		}
-		imports, _ := importsOfFile(file)
-		for pkgName, pkgI := range imports {
-			newP.imports[pkgName] = pkgI
+	var files []*ast.File
+	for _, name := range append(imp.GoFiles, imp.CgoFiles...) {
+		file, err := parser.ParseFile(newP.fileSet, filepath.Join(imp.Dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
 		}
+		files = append(files, file)
+	}
+
+	for ni := range iterPackageInterfaces(files) {
+		newP.importedInterfaces.Set(path, ni.name.Name, ni)
 	}
+	newP.addFileImports(files)
 	return newP, nil
 }
 
@@ -355,23 +499,67 @@ func (p *fileParser) constructTps(it *namedInterface) (tps map[string]model.Type
 // parseInterface loads interface specified by pkg and name, parses it and returns
 // a new model with the parsed.
 func (p *fileParser) parseInterface(name, pkg string, it *namedInterface) (*model.Interface, error) {
-	iface := &model.Interface{Name: name}
+	defer p.useFileImports(it.file)()
+
+	iface := &model.Interface{
+		Name: name,
+		Doc:  it.doc.Text(),
+		Pos:  p.fileSet.Position(it.name.Pos()),
+	}
 	tps := p.constructTps(it)
 	tp, err := p.parseFieldList(pkg, it.typeParams, tps)
 	if err != nil {
-		return nil, fmt.Errorf("unable to parse interface type parameters: %v", name)
+		return nil, fmt.Errorf("unable to parse interface type parameters: %v: %w", name, err)
 	}
 
+	// Report all of the methods that cannot be parsed.
+	var errs []error
 	iface.TypeParams = tp
 	for _, field := range it.it.Methods.List {
-		var methods []*model.Method
-		if methods, err = p.parseMethod(field, it, iface, pkg, tps); err != nil {
+		methods, err := p.parseMethod(field, it, iface, pkg, tps)
+		if errors.Is(err, errConstraintInterface) {
 			return nil, err
 		}
+		if err != nil {
+			if len(field.Names) > 0 {
+				err = fmt.Errorf("method %s: %w", field.Names[0], err)
+			}
+			errs = append(errs, err)
+			continue
+		}
 		for _, m := range methods {
 			iface.AddMethod(m)
 		}
 	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return iface, nil
+}
+
+// parseFuncType parses the named function type and returns the interface
+// synthesized from it.
+func (p *fileParser) parseFuncType(name, pkg string, nf *namedFuncType) (*model.Interface, error) {
+	defer p.useFileImports(nf.file)()
+
+	tps := make(map[string]model.Type)
+	for _, tp := range nf.typeParams {
+		for _, tm := range tp.Names {
+			tps[tm.Name] = nil
+		}
+	}
+	tp, err := p.parseFieldList(pkg, nf.typeParams, tps)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse func type parameters: %v: %w", name, err)
+	}
+
+	in, variadic, out, err := p.parseFunc(pkg, nf.ft, tps)
+	if err != nil {
+		return nil, err
+	}
+	iface := model.NewFuncTypeInterface(name, &model.FuncType{In: in, Out: out, Variadic: variadic}, tp)
+	iface.Doc = nf.doc.Text()
+	iface.Pos = p.fileSet.Position(nf.name.Pos())
 	return iface, nil
 }
 
@@ -385,6 +573,8 @@ func (p *fileParser) parseMethod(field *ast.Field, it *namedInterface, iface *mo
 			}
 			m := &model.Method{
 				Name: field.Names[0].String(),
+				Doc:  field.Doc.Text(),
+				Pos:  p.fileSet.Position(field.Names[0].Pos()),
 			}
 			var err error
 			m.In, m.Variadic, m.Out, err = p.parseFunc(pkg, v, tps)
@@ -439,13 +629,15 @@ func (p *fileParser) parseMethod(field *ast.Field, it *namedInterface, iface *mo
 		case *ast.SelectorExpr:
 			// Embedded interface in another package.
 			filePkg, sel := v.X.(*ast.Ident).String(), v.Sel.String()
-			embeddedPkg, ok := p.imports[filePkg]
+			embeddedPkg, ok, err := p.lookupImport(v.X.Pos(), filePkg)
+			if err != nil {
+				return nil, err
+			}
 			if !ok {
 				return nil, p.errorf(v.X.Pos(), "unknown package %s", filePkg)
 			}
 
 			var embeddedIface *model.Interface
-			var err error
 			embeddedIfaceType := p.auxInterfaces.Get(filePkg, sel)
 			if embeddedIfaceType != nil {
 				embeddedIfaceType.instTypes, err = p.constructInstParams(pkg, it.typeParams, it.instTypes, it.embeddedInstTypeParams, tps)
@@ -500,19 +692,19 @@ func (p *fileParser) parseFunc(pkg string, f *ast.FuncType, tps map[string]model
 			regParams = regParams[:n-1]
 			vp, err := p.parseFieldList(pkg, varParams, tps)
 			if err != nil {
-				return nil, nil, nil, p.errorf(varParams[0].Pos(), "failed parsing variadic argument: %v", err)
+				return nil, nil, nil, p.errorf(varParams[0].Pos(), "failed parsing variadic argument: %w", err)
 			}
 			variadic = vp[0]
 		}
 		inParam, err = p.parseFieldList(pkg, regParams, tps)
 		if err != nil {
-			return nil, nil, nil, p.errorf(f.Pos(), "failed parsing arguments: %v", err)
+			return nil, nil, nil, p.errorf(f.Pos(), "failed parsing arguments: %w", err)
 		}
 	}
 	if f.Results != nil {
 		outParam, err = p.parseFieldList(pkg, f.Results.List, tps)
 		if err != nil {
-			return nil, nil, nil, p.errorf(f.Pos(), "failed parsing returns: %v", err)
+			return nil, nil, nil, p.errorf(f.Pos(), "failed parsing returns: %w", err)
 		}
 	}
 	return
@@ -540,12 +732,12 @@ func (p *fileParser) parseFieldList(pkg string, fields []*ast.Field, tps map[str
 
 		if len(f.Names) == 0 {
 			// anonymous arg
-			ps[i] = &model.Parameter{Type: t}
+			ps[i] = &model.Parameter{Type: t, Pos: p.fileSet.Position(f.Type.Pos())}
 			i++
 			continue
 		}
 		for _, name := range f.Names {
-			ps[i] = &model.Parameter{Name: name.Name, Type: t}
+			ps[i] = &model.Parameter{Name: name.Name, Type: t, Pos: p.fileSet.Position(name.Pos())}
 			i++
 		}
 	}
@@ -598,7 +790,10 @@ func (p *fileParser) parseType(pkg string, typ ast.Expr, tps map[string]model.Ty
 		if v.IsExported() && !ok {
 			// `pkg` may be an aliased imported pkg
 			// if so, patch the import w/ the fully qualified import
-			maybeImportedPkg, ok := p.imports[pkg]
+			maybeImportedPkg, ok, err := p.lookupImport(v.Pos(), pkg)
+			if err != nil {
+				return nil, err
+			}
 			if ok {
 				pkg = maybeImportedPkg.Path()
 			}
@@ -611,8 +806,15 @@ func (p *fileParser) parseType(pkg string, typ ast.Expr, tps map[string]model.Ty
 		// assume predeclared type
 		return model.PredeclaredType(v.Name), nil
 	case *ast.InterfaceType:
+		if v.Methods != nil && len(v.Methods.List) == 1 && len(v.Methods.List[0].Names) == 0 {
+			// A type set such as interface{ ~int | ~float64 }.
+			switch u := v.Methods.List[0].Type.(type) {
+			case *ast.BinaryExpr, *ast.UnaryExpr:
+				return p.parseUnion(pkg, u, tps)
+			}
+		}
 		if v.Methods != nil && len(v.Methods.List) > 0 {
-			return nil, p.errorf(v.Pos(), "can't handle non-empty unnamed interface types")
+			return nil, unsupported(p.errorf(v.Pos(), "can't handle non-empty unnamed interface types"))
 		}
 		return model.PredeclaredType("any"), nil
 	case *ast.MapType:
@@ -627,7 +829,10 @@ func (p *fileParser) parseType(pkg string, typ ast.Expr, tps map[string]model.Ty
 		return &model.MapType{Key: key, Value: value}, nil
 	case *ast.SelectorExpr:
 		pkgName := v.X.(*ast.Ident).String()
-		pkg, ok := p.imports[pkgName]
+		pkg, ok, err := p.lookupImport(v.Pos(), pkgName)
+		if err != nil {
+			return nil, err
+		}
 		if !ok {
 			return nil, p.errorf(v.Pos(), "unknown package %q", pkgName)
 		}
@@ -640,7 +845,7 @@ func (p *fileParser) parseType(pkg string, typ ast.Expr, tps map[string]model.Ty
 		return &model.PointerType{Type: t}, nil
 	case *ast.StructType:
 		if v.Fields != nil && len(v.Fields.List) > 0 {
-			return nil, p.errorf(v.Pos(), "can't handle non-empty unnamed struct types")
+			return nil, unsupported(p.errorf(v.Pos(), "can't handle non-empty unnamed struct types"))
 		}
 		return model.PredeclaredType("struct{}"), nil
 	case *ast.ParenExpr:
@@ -761,7 +966,9 @@ func importsOfFile(file *ast.File) (normalImports map[string]importedPackage, do
 }
 
 type namedInterface struct {
+	file                   *ast.File
 	name                   *ast.Ident
+	doc                    *ast.CommentGroup // may be nil
 	it                     *ast.InterfaceType
 	typeParams             []*ast.Field
 	embeddedInstTypeParams []ast.Expr
@@ -787,7 +994,21 @@ func iterInterfaces(file *ast.File) <-chan *namedInterface {
 					continue
 				}
 
-				ch <- &namedInterface{name: ts.Name, it: it, typeParams: getTypeSpecTypeParams(ts)}
+				ch <- &namedInterface{file: file, name: ts.Name, doc: typeSpecDoc(gd, ts), it: it, typeParams: getTypeSpecTypeParams(ts)}
+			}
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+// iterPackageInterfaces iterates over the interfaces of all files in order.
+func iterPackageInterfaces(files []*ast.File) <-chan *namedInterface {
+	ch := make(chan *namedInterface)
+	go func() {
+		for _, file := range files {
+			for ni := range iterInterfaces(file) {
+				ch <- ni
 			}
 		}
 		close(ch)
@@ -795,6 +1016,65 @@ func iterInterfaces(file *ast.File) <-chan *namedInterface {
 	return ch
 }
 
+type namedFuncType struct {
+	file       *ast.File
+	name       *ast.Ident
+	doc        *ast.CommentGroup // may be nil
+	ft         *ast.FuncType
+	typeParams []*ast.Field
+}
+
+// Create an iterator over all named function types in file.
+func iterFuncTypes(file *ast.File) <-chan *namedFuncType {
+	ch := make(chan *namedFuncType)
+	go func() {
+		for _, decl := range file.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts, ok := spec.(*ast.TypeSpec)
+				if !ok || ts.Assign.IsValid() {
+					continue
+				}
+				ft, ok := ts.Type.(*ast.FuncType)
+				if !ok {
+					continue
+				}
+
+				ch <- &namedFuncType{file: file, name: ts.Name, doc: typeSpecDoc(gd, ts), ft: ft, typeParams: getTypeSpecTypeParams(ts)}
+			}
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+// iterPackageFuncTypes iterates over the named function types of all files
+// in order.
+func iterPackageFuncTypes(files []*ast.File) <-chan *namedFuncType {
+	ch := make(chan *namedFuncType)
+	go func() {
+		for _, file := range files {
+			for nf := range iterFuncTypes(file) {
+				ch <- nf
+			}
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+// typeSpecDoc returns the doc comment of the type declared by ts in gd.
+// The doc comment of an unparenthesized declaration is attached to gd.
+func typeSpecDoc(gd *ast.GenDecl, ts *ast.TypeSpec) *ast.CommentGroup {
+	if ts.Doc == nil && !gd.Lparen.IsValid() {
+		return gd.Doc
+	}
+	return ts.Doc
+}
+
 // isVariadic returns whether the function is variadic.
 func isVariadic(f *ast.FuncType) bool {
 	nargs := len(f.Params.List)
@@ -805,29 +1085,4 @@ func isVariadic(f *ast.FuncType) bool {
 	return ok
 }
 
-// packageNameOfDir get package import path via dir
-func packageNameOfDir(srcDir string) (string, error) {
-	files, err := os.ReadDir(srcDir)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	var goFilePath string
-	for _, file := range files {
-		if !file.IsDir() && strings.HasSuffix(file.Name(), ".go") {
-			goFilePath = file.Name()
-			break
-		}
-	}
-	if goFilePath == "" {
-		return "", fmt.Errorf("go source file not found %s", srcDir)
-	}
-
-	packageImport, err := parsePackageImport(srcDir)
-	if err != nil {
-		return "", err
-	}
-	return packageImport, nil
-}
-
 var errOutsideGoPath = errors.New("source directory is outside GOPATH")
@@ -0,0 +1,485 @@
+package generate
+
+import (
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileParser_ParseFile(t *testing.T) {
+	fs := token.NewFileSet()
+	file, err := parser.ParseFile(fs, "../internal/tests/custom_package_name/greeter/greeter.go", nil, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	p := fileParser{
+		fileSet:            fs,
+		imports:            make(map[string]importedPackage),
+		importedInterfaces: newInterfaceCache(),
+	}
+
+	pkg, err := p.parseFile("", file)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	checkGreeterImports(t, p.fileImports[file])
+
+	expectedName := "greeter"
+	if pkg.Name != expectedName {
+		t.Fatalf("Expected name to be %v but got %v", expectedName, pkg.Name)
+	}
+
+	expectedInterfaceName := "InputMaker"
+	if pkg.Interfaces[0].Name != expectedInterfaceName {
+		t.Fatalf("Expected interface name to be %v but got %v", expectedInterfaceName, pkg.Interfaces[0].Name)
+	}
+}
+
+func TestFileParser_ParsePackage(t *testing.T) {
+	fs := token.NewFileSet()
+	_, err := parser.ParseFile(fs, "../internal/tests/custom_package_name/greeter/greeter.go", nil, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	p := fileParser{
+		fileSet:            fs,
+		imports:            make(map[string]importedPackage),
+		importedInterfaces: newInterfaceCache(),
+	}
+
+	newP, err := p.parsePackage("go.uber.org/mock/mockgen/internal/tests/custom_package_name/greeter")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(newP.fileImports) != 1 {
+		t.Fatalf("Expected imports of 1 file but got %v", len(newP.fileImports))
+	}
+	for _, imports := range newP.fileImports {
+		checkGreeterImports(t, imports)
+	}
+}
+
+func TestImportsOfFile(t *testing.T) {
+	fs := token.NewFileSet()
+	file, err := parser.ParseFile(fs, "../internal/tests/custom_package_name/greeter/greeter.go", nil, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	imports, _ := importsOfFile(file)
+	checkGreeterImports(t, imports)
+}
+
+func checkGreeterImports(t *testing.T, imports map[string]importedPackage) {
+	// check that imports have stdlib package "fmt"
+	if fmtPackage, ok := imports["fmt"]; !ok {
+		t.Errorf("Expected imports to have key \"fmt\"")
+	} else {
+		expectedFmtPackage := "fmt"
+		if fmtPackage.Path() != expectedFmtPackage {
+			t.Errorf("Expected fmt key to have value %s but got %s", expectedFmtPackage, fmtPackage.Path())
+		}
+	}
+
+	// check that imports have package named "validator"
+	if validatorPackage, ok := imports["validator"]; !ok {
+		t.Errorf("Expected imports to have key \"fmt\"")
+	} else {
+		expectedValidatorPackage := "go.uber.org/mock/mockgen/internal/tests/custom_package_name/validator"
+		if validatorPackage.Path() != expectedValidatorPackage {
+			t.Errorf("Expected validator key to have value %s but got %s", expectedValidatorPackage, validatorPackage.Path())
+		}
+	}
+
+	// check that imports have package named "client"
+	if clientPackage, ok := imports["client"]; !ok {
+		t.Errorf("Expected imports to have key \"client\"")
+	} else {
+		expectedClientPackage := "go.uber.org/mock/mockgen/internal/tests/custom_package_name/client/v1"
+		if clientPackage.Path() != expectedClientPackage {
+			t.Errorf("Expected client key to have value %s but got %s", expectedClientPackage, clientPackage.Path())
+		}
+	}
+
+	// check that imports don't have package named "v1"
+	if _, ok := imports["v1"]; ok {
+		t.Errorf("Expected import not to have key \"v1\"")
+	}
+}
+
+func Benchmark_parseFile(b *testing.B) {
+	source := "../internal/tests/performance/big_interface/big_interface.go"
+	for n := 0; n < b.N; n++ {
+		sourceMode(source, nil, LoadOptions{})
+	}
+}
+
+func TestParseArrayWithConstLength(t *testing.T) {
+	fs := token.NewFileSet()
+	srcDir := "../internal/tests/const_array_length/input.go"
+
+	file, err := parser.ParseFile(fs, srcDir, nil, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	p := fileParser{
+		fileSet:            fs,
+		imports:            make(map[string]importedPackage),
+		importedInterfaces: newInterfaceCache(),
+		auxInterfaces:      newInterfaceCache(),
+		srcDir:             srcDir,
+	}
+
+	pkg, err := p.parseFile("", file)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expects := []string{"[2]int", "[2]int", "[127]int", "[3]int", "[3]int", "[7]int"}
+	for i, e := range expects {
+		got := pkg.Interfaces[0].Methods[i].Out[0].Type.String(nil, "")
+		if got != e {
+			t.Fatalf("got %v; expected %v", got, e)
+		}
+	}
+}
+
+func TestParseFile_IncludeOnlyRequested(t *testing.T) {
+	fs := token.NewFileSet()
+	file, err := parser.ParseFile(fs, "../internal/tests/custom_package_name/greeter/greeter.go", nil, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	p := fileParser{
+		fileSet:            fs,
+		imports:            make(map[string]importedPackage),
+		importedInterfaces: newInterfaceCache(),
+		// include только один интерфейс
+		includeNamesSet: map[string]struct{}{"InputMaker": {}},
+	}
+
+	pkg, err := p.parseFile("", file)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(pkg.Interfaces) != 1 || pkg.Interfaces[0].Name != "InputMaker" {
+		t.Fatalf("Expected only InputMaker, got %v", pkg.Interfaces)
+	}
+}
+
+// When requested interface is missing, parser should ignore it (no error, no interfaces).
+func TestParseFile_IncludeMissing_Ignored(t *testing.T) {
+    fs := token.NewFileSet()
+    file, err := parser.ParseFile(fs, "../internal/tests/custom_package_name/greeter/greeter.go", nil, 0)
+    if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+    }
+
+    p := fileParser{
+        fileSet:            fs,
+        imports:            make(map[string]importedPackage),
+        importedInterfaces: newInterfaceCache(),
+        includeNamesSet:    map[string]struct{}{"DoesNotExist": {}},
+    }
+
+    pkg, err := p.parseFile("", file)
+    if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(pkg.Interfaces) != 0 {
+		t.Fatalf("Expected no interfaces, got %v", pkg.Interfaces)
+	}
+}
+
+func TestParseFile_IncludeWithDuplicates_Dedupes(t *testing.T) {
+	fs := token.NewFileSet()
+	file, err := parser.ParseFile(fs, "../internal/tests/custom_package_name/greeter/greeter.go", nil, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Эмулируем «случайно указали дубликаты» как это делает sourceMode (через позиционные аргументы)
+	args := []string{"InputMaker", "InputMaker"} // дубликаты
+	include := make(map[string]struct{})
+	for _, a := range args {
+		for _, name := range strings.Split(a, ",") {
+			name = strings.TrimSpace(name)
+			if name != "" {
+				include[name] = struct{}{}
+			}
+		}
+	}
+
+	p := fileParser{
+		fileSet:            fs,
+		imports:            make(map[string]importedPackage),
+		importedInterfaces: newInterfaceCache(),
+		includeNamesSet:    include,
+	}
+
+	pkg, err := p.parseFile("", file)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(pkg.Interfaces) != 1 || pkg.Interfaces[0].Name != "InputMaker" {
+		t.Fatalf("Expected only InputMaker once, got %v", pkg.Interfaces)
+	}
+}
+
+func TestFileParser_ParseFile_DocComments(t *testing.T) {
+	fs := token.NewFileSet()
+	file, err := parser.ParseFile(fs, "../internal/tests/doc_comments/input.go", nil, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	p := fileParser{
+		fileSet:            fs,
+		imports:            make(map[string]importedPackage),
+		importedInterfaces: newInterfaceCache(),
+		auxInterfaces:      newInterfaceCache(),
+		srcDir:             "../internal/tests/doc_comments",
+	}
+
+	pkg, err := p.parseFile("go.uber.org/mock/mockgen/internal/tests/doc_comments", file)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	intf := pkg.Interfaces[0]
+	if want := "Store persists blobs.\n\nImplementations must be safe for concurrent use.\n"; intf.Doc != want {
+		t.Errorf("Expected interface doc %q but got %q", want, intf.Doc)
+	}
+	if intf.Pos.Line != 11 {
+		t.Errorf("Expected interface at line 11 but got %v", intf.Pos)
+	}
+
+	docs := make(map[string]string)
+	for _, m := range intf.Methods {
+		docs[m.Name] = m.Doc
+		if m.Name == "Put" {
+			if m.Pos.Line != 17 || m.In[1].Name != "blob" || m.In[1].Pos.Line != 17 {
+				t.Errorf("Expected Put and its blob parameter at line 17 but got %v and %v", m.Pos, m.In[1].Pos)
+			}
+		}
+	}
+	expected := map[string]string{
+		"Close": "",
+		"Get":   "Get returns the blob stored under key.\n",
+		"Put":   "Put stores blob under key, replacing any previous blob.\n",
+		"Len":   "",
+	}
+	for name, want := range expected {
+		if got := docs[name]; got != want {
+			t.Errorf("Expected doc of %v to be %q but got %q", name, want, got)
+		}
+	}
+}
+
+func TestSourceMode_Package(t *testing.T) {
+	for _, source := range []string{
+		"../internal/tests/source_package",
+		"go.uber.org/mock/mockgen/internal/tests/source_package",
+	} {
+		pkg, err := sourceMode(source, []string{"Store"}, LoadOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error for %v: %v", source, err)
+		}
+		if pkg.Name != "source_package" || pkg.PkgPath != "go.uber.org/mock/mockgen/internal/tests/source_package" {
+			t.Errorf("Expected package source_package but got %v (%v)", pkg.Name, pkg.PkgPath)
+		}
+		if len(pkg.Interfaces) != 1 {
+			t.Fatalf("Expected 1 interface but got %v", len(pkg.Interfaces))
+		}
+
+		// Get is embedded from getter.go and Close from io.Closer.
+		var names []string
+		for _, m := range pkg.Interfaces[0].Methods {
+			names = append(names, m.Name)
+		}
+		if got, want := strings.Join(names, ","), "Get,Close,Put"; got != want {
+			t.Errorf("Expected methods %v but got %v", want, got)
+		}
+	}
+}
+
+func TestSourceMode_BuildTags(t *testing.T) {
+	const (
+		dir        = "../internal/tests/source_build_tags"
+		importPath = "go.uber.org/mock/mockgen/internal/tests/source_build_tags"
+	)
+	methods := func(opts LoadOptions, source string) string {
+		t.Helper()
+		pkg, err := sourceMode(source, []string{"Store"}, opts)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		var names []string
+		for _, m := range pkg.Interfaces[0].Methods {
+			names = append(names, m.Name)
+		}
+		return strings.Join(names, ",")
+	}
+
+	if got, want := methods(LoadOptions{}, dir), "Get,Close"; got != want {
+		t.Errorf("Expected methods %v without tags but got %v", want, got)
+	}
+	for _, flags := range [][]string{{"-tags=integration"}, {"-race", "-tags", "integration"}} {
+		if got, want := methods(LoadOptions{BuildFlags: flags}, dir), "Query,Close"; got != want {
+			t.Errorf("Expected methods %v with %v but got %v", want, flags, got)
+		}
+	}
+
+	// backend_plan9.go is excluded by its file name suffix, so Backend is
+	// loaded from the package instead.
+	opts := LoadOptions{AuxFiles: []AuxFile{{Package: importPath, Path: dir + "/backend_plan9.go"}}}
+	if build.Default.GOOS != "plan9" {
+		if got, want := methods(opts, dir+"/store.go"), "Get,Close"; got != want {
+			t.Errorf("Expected methods %v but got %v", want, got)
+		}
+	}
+}
+
+func TestSourceMode_Unsupported(t *testing.T) {
+	const source = "../internal/tests/unsupported/input.go"
+
+	_, err := sourceMode(source, nil, LoadOptions{})
+	if err == nil || !strings.Contains(err.Error(), "interface Visitor: method Visit: ") {
+		t.Errorf("Expected error for Visitor.Visit but got %v", err)
+	}
+	_, err = sourceMode(source, []string{"Number", "Store"}, LoadOptions{})
+	if err == nil || !strings.Contains(err.Error(), "interface Number: interface Number is a constraint") {
+		t.Errorf("Expected error for Number but got %v", err)
+	}
+
+	pkg, err := sourceMode(source, nil, LoadOptions{SkipUnsupported: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var names []string
+	for _, intf := range pkg.Interfaces {
+		names = append(names, intf.Name)
+	}
+	if got, want := strings.Join(names, ","), "Sum,Scaler,Store"; got != want {
+		t.Errorf("Expected interfaces %v but got %v", want, got)
+	}
+	if got, want := pkg.Interfaces[0].TypeParams[0].Type.String(nil, ""), "~int | ~float64"; got != want {
+		t.Errorf("Expected constraint %v but got %v", want, got)
+	}
+}
+
+func TestSourceMode_DuplicateImport(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/input\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	source := filepath.Join(dir, "input.go")
+	src := `package input
+
+import (
+	"example.com/a/util"
+	"example.com/b/util"
+)
+
+type Foo interface {
+	Bar() util.Thing
+}
+`
+	if err := os.WriteFile(source, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := sourceMode(source, nil, LoadOptions{})
+	if err == nil || !strings.Contains(err.Error(), `"util" is ambiguous because of duplicate imports`) {
+		t.Errorf("Expected duplicate import error but got %v", err)
+	}
+}
+
+func TestSourceMode_PerFileImports(t *testing.T) {
+	dir := t.TempDir()
+	for name, src := range map[string]string{
+		"go.mod": "module example.com/input\n",
+		"a.go": `package input
+
+import "example.com/a/util"
+
+type A interface {
+	Get() util.Thing
+}
+`,
+		"b.go": `package input
+
+import "example.com/b/util"
+
+type B interface {
+	A
+	Put(util.Thing)
+}
+`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pkg, err := sourceMode(dir, []string{"B"}, LoadOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	pm := map[string]string{"example.com/a/util": "autil", "example.com/b/util": "butil"}
+	var types []string
+	for _, m := range pkg.Interfaces[0].Methods {
+		for _, p := range append(m.In, m.Out...) {
+			types = append(types, m.Name+" "+p.Type.String(pm, ""))
+		}
+	}
+	if got, want := strings.Join(types, ","), "Get autil.Thing,Put butil.Thing"; got != want {
+		t.Errorf("Expected types %v but got %v", want, got)
+	}
+}
+
+func TestSourceMode_SkipUnsupportedReportsOtherErrors(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/input\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	source := filepath.Join(dir, "input.go")
+	src := `package input
+
+type Visitor interface {
+	Visit(node interface{ Pos() int }) error
+}
+
+type Broken interface {
+	Visit(node interface{ Pos() int }) error
+	Get() missing.Thing
+}
+
+type Store interface {
+	Get(key string) (string, error)
+}
+`
+	if err := os.WriteFile(source, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := sourceMode(source, nil, LoadOptions{SkipUnsupported: true})
+	if err == nil || !strings.Contains(err.Error(), "interface Broken: ") || !strings.Contains(err.Error(), "method Get: ") || !strings.Contains(err.Error(), `unknown package "missing"`) {
+		t.Fatalf("Expected error for Broken.Get but got %v", err)
+	}
+	if strings.Contains(err.Error(), "interface Visitor") {
+		t.Errorf("Expected unsupported Visitor to be skipped but got %v", err)
+	}
+}
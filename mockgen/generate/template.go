@@ -0,0 +1,130 @@
+package generate
+
+// This file contains the rendering of the generated code with text/template.
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"go.uber.org/mock/mockgen/model"
+)
+
+// DefaultTemplate is the template of the generated code used when
+// [Options.Template] is empty: the file header followed by the mock of each
+// interface. Custom templates typically extend it with extra declarations.
+//
+// Templates are executed with a [TemplateData] and the following functions:
+//
+//	header              the file header: comments, package clause and imports
+//	mock INTERFACE      the built-in mock, or fake, of the interface
+//	mockName INTERFACE  the name of the mock of the interface
+//	typeParams INTERFACE  the type parameter list of the mock, e.g. [T any]
+//	typeArgs INTERFACE  the type arguments of the mock, e.g. [T]
+//	type TYPE           the type as written in the generated package
+//	argNames METHOD     the names of the method's parameters
+//	args METHOD         the method's parameter list, e.g. ctx context.Context, id string
+//	results METHOD      the method's result list, e.g. (string, error)
+//
+// The imports are those needed by the built-in mocks. Missing imports of
+// standard packages used by a custom template are added when formatting.
+const DefaultTemplate = `{{header}}
+{{- range .Package.Interfaces}}{{mock .}}{{end}}
+`
+
+// TemplateData is the data the template of the generated code is executed
+// with.
+type TemplateData struct {
+	// Package is the model of the mocked interfaces.
+	Package *model.Package
+	// PackageName is the name of the generated package.
+	PackageName string
+	// OutputPackagePath is the import path of the generated package. It may
+	// be empty.
+	OutputPackagePath string
+	// PackageMap maps the import paths of the imported packages to their
+	// local names.
+	PackageMap map[string]string
+}
+
+// executeTemplate renders g.template, or DefaultTemplate, with data.
+func (g *generator) executeTemplate(data *TemplateData) error {
+	text := g.template
+	if text == "" {
+		text = DefaultTemplate
+	}
+	tmpl, err := template.New("mockgen").Funcs(g.templateFuncs(data)).Parse(text)
+	if err != nil {
+		return fmt.Errorf("parse template: %w", err)
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return fmt.Errorf("execute template: %w", err)
+	}
+	g.buf.Reset()
+	g.buf.Write(out.Bytes())
+	return nil
+}
+
+// templateFuncs returns the functions of templates executed with data.
+func (g *generator) templateFuncs(data *TemplateData) template.FuncMap {
+	pkgOverride := data.OutputPackagePath
+
+	// capture returns the code written by f.
+	capture := func(f func() error) (string, error) {
+		g.buf.Reset()
+		err := f()
+		return g.buf.String(), err
+	}
+
+	return template.FuncMap{
+		"header": func() (string, error) {
+			return capture(func() error {
+				g.GenerateHeader(data.Package, data.PackageName, data.OutputPackagePath)
+				return nil
+			})
+		},
+		"mock": func(intf *model.Interface) (string, error) {
+			return capture(func() error {
+				if g.style == StyleFake {
+					return g.GenerateFakeInterface(intf, pkgOverride)
+				}
+				return g.GenerateMockInterface(intf, pkgOverride)
+			})
+		},
+		"mockName": func(intf *model.Interface) string {
+			if g.style == StyleFake {
+				return g.fakeName(intf.Name)
+			}
+			return g.mockName(intf.Name)
+		},
+		"typeParams": func(intf *model.Interface) string {
+			long, _ := g.formattedTypeParams(intf, pkgOverride)
+			return long
+		},
+		"typeArgs": func(intf *model.Interface) string {
+			_, short := g.formattedTypeParams(intf, pkgOverride)
+			return short
+		},
+		"type": func(t model.Type) string {
+			return t.String(g.packageMap, pkgOverride)
+		},
+		"argNames": func(m *model.Method) []string {
+			return g.getArgNames(m, true /* in */)
+		},
+		"args": func(m *model.Method) string {
+			return makeArgString(g.getArgNames(m, true /* in */), g.getArgTypes(m, pkgOverride, true /* in */))
+		},
+		"results": func(m *model.Method) string {
+			rets := make([]string, len(m.Out))
+			for i, p := range m.Out {
+				rets[i] = p.Type.String(g.packageMap, pkgOverride)
+			}
+			if len(rets) > 1 {
+				return "(" + strings.Join(rets, ", ") + ")"
+			}
+			return strings.Join(rets, "")
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package generate
+
+// This file contains the reporting of the declarations that cannot be mocked.
+
+import (
+	"errors"
+	"log"
+)
+
+// An unsupportedError reports a declaration that cannot be modeled, such as
+// an interface with a method taking a non-empty unnamed interface. It wraps
+// an error for each unsupported part of the declaration.
+type unsupportedError struct {
+	err error
+}
+
+// unsupported returns the unsupportedError for errs, or nil if there are
+// none.
+func unsupported(errs ...error) error {
+	err := errors.Join(errs...)
+	if err == nil {
+		return nil
+	}
+	return &unsupportedError{err: err}
+}
+
+func (e *unsupportedError) Error() string { return e.err.Error() }
+func (e *unsupportedError) Unwrap() error { return e.err }
+
+// isUnsupported reports whether err only reports unsupported parts of a
+// declaration: every error joined in err must wrap an unsupportedError.
+// Other errors, such as unresolved imports, are never skipped.
+func isUnsupported(err error) bool {
+	switch e := err.(type) {
+	case *unsupportedError:
+		return true
+	case interface{ Unwrap() []error }:
+		for _, err := range e.Unwrap() {
+			if !isUnsupported(err) {
+				return false
+			}
+		}
+		return true
+	case interface{ Unwrap() error }:
+		return isUnsupported(e.Unwrap())
+	}
+	return false
+}
+
+// skipUnsupported reports whether the declaration name, which failed to be
+// parsed with err, is skipped because skip is set and it is unsupported.
+// Skipped declarations are logged.
+func skipUnsupported(skip bool, name string, err error) bool {
+	if !skip || !isUnsupported(err) {
+		return false
+	}
+	log.Printf("Skipping unsupported %s: %v", name, err)
+	return true
+}
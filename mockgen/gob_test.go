@@ -8,15 +8,17 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/mockgen/generate"
 )
 
 func TestGobMode(t *testing.T) {
 
 	// Encode a package to a temporary gob.
-	parser := packageModeParser{}
-	want, err := parser.parsePackage(
+	want, err := generate.LoadPackage(
 		"go.uber.org/mock/mockgen/internal/tests/package_mode", /* package name */
 		[]string{"Human", "Earth"},                             /* ifaces */
+		generate.LoadOptions{},
 	)
 	require.NoError(t, err)
 	path := filepath.Join(t.TempDir(), "model.gob")
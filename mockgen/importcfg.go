@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// A stringList is the value of a flag that may be repeated.
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+// stringListFlag defines a repeated flag with the given name and usage.
+func stringListFlag(name, usage string) *stringList {
+	var l stringList
+	flag.Var(&l, name, usage)
+	return &l
+}
+
+// parseImportcfg returns the export data files of the packagefile lines of
+// the compiler importcfg data. Other directives are ignored.
+func parseImportcfg(data string) (map[string]string, error) {
+	files := make(map[string]string)
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		verb, args, _ := strings.Cut(line, " ")
+		if verb != "packagefile" {
+			continue
+		}
+		pkgPath, file, ok := strings.Cut(args, "=")
+		pkgPath, file = strings.TrimSpace(pkgPath), strings.TrimSpace(file)
+		if !ok || pkgPath == "" || file == "" {
+			return nil, fmt.Errorf("line %d: bad packagefile %q, want packagefile path=file", i+1, line)
+		}
+		files[pkgPath] = file
+	}
+	return files, nil
+}
+
+// archiveDeps returns the export data files of the dependencies given by
+// the -importcfg file and the -archive_dep specs, which take precedence.
+func archiveDeps(importcfg string, specs []string) (map[string]string, error) {
+	deps := make(map[string]string)
+	if importcfg != "" {
+		data, err := os.ReadFile(importcfg)
+		if err != nil {
+			return nil, err
+		}
+		if deps, err = parseImportcfg(string(data)); err != nil {
+			return nil, fmt.Errorf("%s: %w", importcfg, err)
+		}
+	}
+	for _, spec := range specs {
+		pkgPath, file, ok := strings.Cut(spec, "=")
+		if !ok || pkgPath == "" || file == "" {
+			return nil, fmt.Errorf("bad archive dep spec %q, want path=file", spec)
+		}
+		deps[pkgPath] = file
+	}
+	if len(deps) == 0 {
+		return nil, nil
+	}
+	return deps, nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImportcfg(t *testing.T) {
+	files, err := parseImportcfg(`# import config
+packagefile io=/cache/io.a
+packagefile example.com/x = /cache/x.a
+importmap old=new
+modinfo "..."
+`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"io":            "/cache/io.a",
+		"example.com/x": "/cache/x.a",
+	}, files)
+
+	_, err = parseImportcfg("packagefile io=/cache/io.a\npackagefile io\n")
+	assert.EqualError(t, err, `line 2: bad packagefile "packagefile io", want packagefile path=file`)
+}
+
+func TestArchiveDeps(t *testing.T) {
+	deps, err := archiveDeps("", nil)
+	require.NoError(t, err)
+	assert.Nil(t, deps)
+
+	importcfg := filepath.Join(t.TempDir(), "importcfg")
+	require.NoError(t, os.WriteFile(importcfg, []byte("packagefile io=/cache/io.a\npackagefile fmt=/cache/fmt.a\n"), 0o644))
+	deps, err = archiveDeps(importcfg, []string{"io=/other/io.a", "example.com/x=/cache/x.a"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"io":            "/other/io.a",
+		"fmt":           "/cache/fmt.a",
+		"example.com/x": "/cache/x.a",
+	}, deps)
+
+	_, err = archiveDeps("", []string{"io"})
+	assert.EqualError(t, err, `bad archive dep spec "io", want path=file`)
+	_, err = archiveDeps(filepath.Join(t.TempDir(), "missing"), nil)
+	assert.Error(t, err)
+}
@@ -77,6 +77,12 @@ func (c *MockFooerFooCall) DoAndReturn(f func()) *MockFooerFooCall {
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockFooerFooCall) Panic(v any) *MockFooerFooCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // MockFooerAlias is a mock of FooerAlias interface.
 type MockFooerAlias struct {
 	ctrl     *gomock.Controller
@@ -137,6 +143,12 @@ func (c *MockFooerAliasFooCall) DoAndReturn(f func()) *MockFooerAliasFooCall {
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockFooerAliasFooCall) Panic(v any) *MockFooerAliasFooCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // MockBarer is a mock of Barer interface.
 type MockBarer struct {
 	ctrl     *gomock.Controller
@@ -199,6 +211,12 @@ func (c *MockBarerBarCall) DoAndReturn(f func(alias.FooerAlias) alias.FooerAlias
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBarerBarCall) Panic(v any) *MockBarerBarCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // MockBarerAlias is a mock of BarerAlias interface.
 type MockBarerAlias struct {
 	ctrl     *gomock.Controller
@@ -261,6 +279,12 @@ func (c *MockBarerAliasBarCall) DoAndReturn(f func(alias.FooerAlias) alias.Fooer
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBarerAliasBarCall) Panic(v any) *MockBarerAliasBarCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // MockBazer is a mock of Bazer interface.
 type MockBazer struct {
 	ctrl     *gomock.Controller
@@ -323,6 +347,12 @@ func (c *MockBazerBazCall) DoAndReturn(f func(alias.Fooer) alias.Fooer) *MockBaz
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBazerBazCall) Panic(v any) *MockBazerBazCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // MockQuxerConsumer is a mock of QuxerConsumer interface.
 type MockQuxerConsumer struct {
 	ctrl     *gomock.Controller
@@ -385,6 +415,12 @@ func (c *MockQuxerConsumerConsumeCall) DoAndReturn(f func(alias.QuxerAlias) alia
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockQuxerConsumerConsumeCall) Panic(v any) *MockQuxerConsumerConsumeCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // MockQuuxerConsumer is a mock of QuuxerConsumer interface.
 type MockQuuxerConsumer struct {
 	ctrl     *gomock.Controller
@@ -446,3 +482,9 @@ func (c *MockQuuxerConsumerConsumeCall) DoAndReturn(f func(subpkg.Quuxer) subpkg
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// Panic rewrite *gomock.Call.Panic
+func (c *MockQuuxerConsumerConsumeCall) Panic(v any) *MockQuuxerConsumerConsumeCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
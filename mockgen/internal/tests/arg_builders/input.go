@@ -0,0 +1,15 @@
+package arg_builders
+
+import "context"
+
+//go:generate mockgen -package arg_builders -source=input.go -destination=mock.go -arg_builders=4
+//go:generate mockgen -package arg_builders -source=input.go -destination=typed_mock.go -arg_builders=4 -typed -mock_names=Store=MockTypedStore
+
+type Store interface {
+	// Find has a builder.
+	Find(ctx context.Context, userID string, limit, offset int, sort string, includeDeleted bool) ([]string, error)
+	// Search has a builder with a variadic parameter.
+	Search(ctx context.Context, query string, limit int, tags ...string) []string
+	// Get has too few parameters for a builder.
+	Get(ctx context.Context, id string) (string, error)
+}
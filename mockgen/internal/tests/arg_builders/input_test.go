@@ -0,0 +1,74 @@
+package arg_builders
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestFindWith(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockStore(ctrl)
+
+	m.EXPECT().FindWith().UserID("u1").Limit(10).Call().Return([]string{"a"}, nil)
+
+	got, err := m.Find(context.Background(), "u1", 10, 20, "name", true)
+	if err != nil || len(got) != 1 || got[0] != "a" {
+		t.Fatalf("Find() = %v, %v", got, err)
+	}
+}
+
+func TestFindWith_Mismatch(t *testing.T) {
+	reporter := &mockReporter{T: t}
+	ctrl := gomock.NewController(reporter)
+	m := NewMockStore(ctrl)
+
+	m.EXPECT().FindWith().UserID("u1").Call().AnyTimes()
+
+	func() {
+		defer func() { _ = recover() }()
+		m.Find(context.Background(), "u2", 10, 20, "name", true)
+	}()
+	if !reporter.failed {
+		t.Fatal("Find() with an unexpected userID did not fail")
+	}
+}
+
+func TestSearchWith(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockStore(ctrl)
+
+	m.EXPECT().SearchWith().Query("q").Tags("x", gomock.Any()).Call().Return([]string{"tagged"})
+	m.EXPECT().SearchWith().Query("q").Call().Return([]string{"any"})
+
+	if got := m.Search(context.Background(), "q", 1, "x", "y"); len(got) != 1 || got[0] != "tagged" {
+		t.Fatalf("Search() = %v, want [tagged]", got)
+	}
+	if got := m.Search(context.Background(), "q", 1); len(got) != 1 || got[0] != "any" {
+		t.Fatalf("Search() = %v, want [any]", got)
+	}
+}
+
+func TestTypedFindWith(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockTypedStore(ctrl)
+
+	m.EXPECT().FindWith().Sort("name").Call().DoAndReturn(
+		func(_ context.Context, userID string, _, _ int, _ string, _ bool) ([]string, error) {
+			return []string{userID}, nil
+		})
+
+	got, err := m.Find(context.Background(), "u1", 10, 20, "name", false)
+	if err != nil || len(got) != 1 || got[0] != "u1" {
+		t.Fatalf("Find() = %v, %v", got, err)
+	}
+}
+
+type mockReporter struct {
+	*testing.T
+	failed bool
+}
+
+func (r *mockReporter) Errorf(format string, args ...any) { r.failed = true }
+func (r *mockReporter) Fatalf(format string, args ...any) { r.failed = true; panic("fatal") }
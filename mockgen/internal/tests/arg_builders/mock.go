@@ -0,0 +1,187 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+//
+// Generated by this command:
+//
+//	mockgen -package arg_builders -source=input.go -destination=mock.go -arg_builders=4
+//
+
+// Package arg_builders is a generated GoMock package.
+package arg_builders
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStore is a mock of Store interface.
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore.
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance.
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockStore) Find(ctx context.Context, userID string, limit, offset int, sort string, includeDeleted bool) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, userID, limit, offset, sort, includeDeleted)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockStoreMockRecorder) Find(ctx, userID, limit, offset, sort, includeDeleted any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockStore)(nil).Find), ctx, userID, limit, offset, sort, includeDeleted)
+}
+
+// FindWith returns a builder of an expected call of Find whose arguments are
+// set by name. Unset arguments match anything.
+func (mr *MockStoreMockRecorder) FindWith() *MockStoreFindBuilder {
+	return &MockStoreFindBuilder{mock: mr.mock, args: []any{gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()}}
+}
+
+// MockStoreFindBuilder builds an expected call of Find.
+type MockStoreFindBuilder struct {
+	mock *MockStore
+	args []any
+}
+
+// Ctx sets the matcher of the ctx argument.
+func (b *MockStoreFindBuilder) Ctx(ctx any) *MockStoreFindBuilder {
+	b.args[0] = ctx
+	return b
+}
+
+// UserID sets the matcher of the userID argument.
+func (b *MockStoreFindBuilder) UserID(userID any) *MockStoreFindBuilder {
+	b.args[1] = userID
+	return b
+}
+
+// Limit sets the matcher of the limit argument.
+func (b *MockStoreFindBuilder) Limit(limit any) *MockStoreFindBuilder {
+	b.args[2] = limit
+	return b
+}
+
+// Offset sets the matcher of the offset argument.
+func (b *MockStoreFindBuilder) Offset(offset any) *MockStoreFindBuilder {
+	b.args[3] = offset
+	return b
+}
+
+// Sort sets the matcher of the sort argument.
+func (b *MockStoreFindBuilder) Sort(sort any) *MockStoreFindBuilder {
+	b.args[4] = sort
+	return b
+}
+
+// IncludeDeleted sets the matcher of the includeDeleted argument.
+func (b *MockStoreFindBuilder) IncludeDeleted(includeDeleted any) *MockStoreFindBuilder {
+	b.args[5] = includeDeleted
+	return b
+}
+
+// Call records the expected call of Find.
+func (b *MockStoreFindBuilder) Call() *gomock.Call {
+	b.mock.ctrl.T.Helper()
+	return b.mock.ctrl.RecordCallWithMethodType(b.mock, "Find", reflect.TypeOf((*MockStore)(nil).Find), b.args...)
+}
+
+// Get mocks base method.
+func (m *MockStore) Get(ctx context.Context, id string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockStoreMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStore)(nil).Get), ctx, id)
+}
+
+// Search mocks base method.
+func (m *MockStore) Search(ctx context.Context, query string, limit int, tags ...string) []string {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, query, limit}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Search", varargs...)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockStoreMockRecorder) Search(ctx, query, limit any, tags ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, query, limit}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockStore)(nil).Search), varargs...)
+}
+
+// SearchWith returns a builder of an expected call of Search whose arguments are
+// set by name. Unset arguments match anything.
+func (mr *MockStoreMockRecorder) SearchWith() *MockStoreSearchBuilder {
+	return &MockStoreSearchBuilder{mock: mr.mock, args: []any{gomock.Any(), gomock.Any(), gomock.Any()}, varargs: []any{gomock.Any()}}
+}
+
+// MockStoreSearchBuilder builds an expected call of Search.
+type MockStoreSearchBuilder struct {
+	mock    *MockStore
+	args    []any
+	varargs []any
+}
+
+// Ctx sets the matcher of the ctx argument.
+func (b *MockStoreSearchBuilder) Ctx(ctx any) *MockStoreSearchBuilder {
+	b.args[0] = ctx
+	return b
+}
+
+// Query sets the matcher of the query argument.
+func (b *MockStoreSearchBuilder) Query(query any) *MockStoreSearchBuilder {
+	b.args[1] = query
+	return b
+}
+
+// Limit sets the matcher of the limit argument.
+func (b *MockStoreSearchBuilder) Limit(limit any) *MockStoreSearchBuilder {
+	b.args[2] = limit
+	return b
+}
+
+// Tags sets the matchers of the variadic tags arguments.
+func (b *MockStoreSearchBuilder) Tags(tags ...any) *MockStoreSearchBuilder {
+	b.varargs = tags
+	return b
+}
+
+// Call records the expected call of Search.
+func (b *MockStoreSearchBuilder) Call() *gomock.Call {
+	b.mock.ctrl.T.Helper()
+	return b.mock.ctrl.RecordCallWithMethodType(b.mock, "Search", reflect.TypeOf((*MockStore)(nil).Search), append(b.args, b.varargs...)...)
+}
@@ -0,0 +1,291 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+//
+// Generated by this command:
+//
+//	mockgen -package arg_builders -source=input.go -destination=typed_mock.go -arg_builders=4 -typed -mock_names=Store=MockTypedStore
+//
+
+// Package arg_builders is a generated GoMock package.
+package arg_builders
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTypedStore is a mock of Store interface.
+type MockTypedStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTypedStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockTypedStoreMockRecorder is the mock recorder for MockTypedStore.
+type MockTypedStoreMockRecorder struct {
+	mock *MockTypedStore
+}
+
+// NewMockTypedStore creates a new mock instance.
+func NewMockTypedStore(ctrl *gomock.Controller) *MockTypedStore {
+	mock := &MockTypedStore{ctrl: ctrl}
+	mock.recorder = &MockTypedStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTypedStore) EXPECT() *MockTypedStoreMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockTypedStore) Find(ctx context.Context, userID string, limit, offset int, sort string, includeDeleted bool) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, userID, limit, offset, sort, includeDeleted)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockTypedStoreMockRecorder) Find(ctx, userID, limit, offset, sort, includeDeleted any) *MockTypedStoreFindCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockTypedStore)(nil).Find), ctx, userID, limit, offset, sort, includeDeleted)
+	return &MockTypedStoreFindCall{Call: call}
+}
+
+// FindWith returns a builder of an expected call of Find whose arguments are
+// set by name. Unset arguments match anything.
+func (mr *MockTypedStoreMockRecorder) FindWith() *MockTypedStoreFindBuilder {
+	return &MockTypedStoreFindBuilder{mock: mr.mock, args: []any{gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()}}
+}
+
+// MockTypedStoreFindBuilder builds an expected call of Find.
+type MockTypedStoreFindBuilder struct {
+	mock *MockTypedStore
+	args []any
+}
+
+// Ctx sets the matcher of the ctx argument.
+func (b *MockTypedStoreFindBuilder) Ctx(ctx any) *MockTypedStoreFindBuilder {
+	b.args[0] = ctx
+	return b
+}
+
+// UserID sets the matcher of the userID argument.
+func (b *MockTypedStoreFindBuilder) UserID(userID any) *MockTypedStoreFindBuilder {
+	b.args[1] = userID
+	return b
+}
+
+// Limit sets the matcher of the limit argument.
+func (b *MockTypedStoreFindBuilder) Limit(limit any) *MockTypedStoreFindBuilder {
+	b.args[2] = limit
+	return b
+}
+
+// Offset sets the matcher of the offset argument.
+func (b *MockTypedStoreFindBuilder) Offset(offset any) *MockTypedStoreFindBuilder {
+	b.args[3] = offset
+	return b
+}
+
+// Sort sets the matcher of the sort argument.
+func (b *MockTypedStoreFindBuilder) Sort(sort any) *MockTypedStoreFindBuilder {
+	b.args[4] = sort
+	return b
+}
+
+// IncludeDeleted sets the matcher of the includeDeleted argument.
+func (b *MockTypedStoreFindBuilder) IncludeDeleted(includeDeleted any) *MockTypedStoreFindBuilder {
+	b.args[5] = includeDeleted
+	return b
+}
+
+// Call records the expected call of Find.
+func (b *MockTypedStoreFindBuilder) Call() *MockTypedStoreFindCall {
+	b.mock.ctrl.T.Helper()
+	call := b.mock.ctrl.RecordCallWithMethodType(b.mock, "Find", reflect.TypeOf((*MockTypedStore)(nil).Find), b.args...)
+	return &MockTypedStoreFindCall{Call: call}
+}
+
+// MockTypedStoreFindCall wrap *gomock.Call
+type MockTypedStoreFindCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTypedStoreFindCall) Return(arg0 []string, arg1 error) *MockTypedStoreFindCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTypedStoreFindCall) Do(f func(context.Context, string, int, int, string, bool) ([]string, error)) *MockTypedStoreFindCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTypedStoreFindCall) DoAndReturn(f func(context.Context, string, int, int, string, bool) ([]string, error)) *MockTypedStoreFindCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Panic rewrite *gomock.Call.Panic
+func (c *MockTypedStoreFindCall) Panic(v any) *MockTypedStoreFindCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
+// ReturnError rewrite *gomock.Call.ReturnError
+func (c *MockTypedStoreFindCall) ReturnError(err error) *MockTypedStoreFindCall {
+	c.Call = c.Call.ReturnError(err)
+	return c
+}
+
+// Get mocks base method.
+func (m *MockTypedStore) Get(ctx context.Context, id string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTypedStoreMockRecorder) Get(ctx, id any) *MockTypedStoreGetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTypedStore)(nil).Get), ctx, id)
+	return &MockTypedStoreGetCall{Call: call}
+}
+
+// MockTypedStoreGetCall wrap *gomock.Call
+type MockTypedStoreGetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTypedStoreGetCall) Return(arg0 string, arg1 error) *MockTypedStoreGetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTypedStoreGetCall) Do(f func(context.Context, string) (string, error)) *MockTypedStoreGetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTypedStoreGetCall) DoAndReturn(f func(context.Context, string) (string, error)) *MockTypedStoreGetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Panic rewrite *gomock.Call.Panic
+func (c *MockTypedStoreGetCall) Panic(v any) *MockTypedStoreGetCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
+// ReturnError rewrite *gomock.Call.ReturnError
+func (c *MockTypedStoreGetCall) ReturnError(err error) *MockTypedStoreGetCall {
+	c.Call = c.Call.ReturnError(err)
+	return c
+}
+
+// Search mocks base method.
+func (m *MockTypedStore) Search(ctx context.Context, query string, limit int, tags ...string) []string {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, query, limit}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Search", varargs...)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockTypedStoreMockRecorder) Search(ctx, query, limit any, tags ...any) *MockTypedStoreSearchCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, query, limit}, tags...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockTypedStore)(nil).Search), varargs...)
+	return &MockTypedStoreSearchCall{Call: call}
+}
+
+// SearchWith returns a builder of an expected call of Search whose arguments are
+// set by name. Unset arguments match anything.
+func (mr *MockTypedStoreMockRecorder) SearchWith() *MockTypedStoreSearchBuilder {
+	return &MockTypedStoreSearchBuilder{mock: mr.mock, args: []any{gomock.Any(), gomock.Any(), gomock.Any()}, varargs: []any{gomock.Any()}}
+}
+
+// MockTypedStoreSearchBuilder builds an expected call of Search.
+type MockTypedStoreSearchBuilder struct {
+	mock    *MockTypedStore
+	args    []any
+	varargs []any
+}
+
+// Ctx sets the matcher of the ctx argument.
+func (b *MockTypedStoreSearchBuilder) Ctx(ctx any) *MockTypedStoreSearchBuilder {
+	b.args[0] = ctx
+	return b
+}
+
+// Query sets the matcher of the query argument.
+func (b *MockTypedStoreSearchBuilder) Query(query any) *MockTypedStoreSearchBuilder {
+	b.args[1] = query
+	return b
+}
+
+// Limit sets the matcher of the limit argument.
+func (b *MockTypedStoreSearchBuilder) Limit(limit any) *MockTypedStoreSearchBuilder {
+	b.args[2] = limit
+	return b
+}
+
+// Tags sets the matchers of the variadic tags arguments.
+func (b *MockTypedStoreSearchBuilder) Tags(tags ...any) *MockTypedStoreSearchBuilder {
+	b.varargs = tags
+	return b
+}
+
+// Call records the expected call of Search.
+func (b *MockTypedStoreSearchBuilder) Call() *MockTypedStoreSearchCall {
+	b.mock.ctrl.T.Helper()
+	call := b.mock.ctrl.RecordCallWithMethodType(b.mock, "Search", reflect.TypeOf((*MockTypedStore)(nil).Search), append(b.args, b.varargs...)...)
+	return &MockTypedStoreSearchCall{Call: call}
+}
+
+// MockTypedStoreSearchCall wrap *gomock.Call
+type MockTypedStoreSearchCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTypedStoreSearchCall) Return(arg0 []string) *MockTypedStoreSearchCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTypedStoreSearchCall) Do(f func(context.Context, string, int, ...string) []string) *MockTypedStoreSearchCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTypedStoreSearchCall) DoAndReturn(f func(context.Context, string, int, ...string) []string) *MockTypedStoreSearchCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Panic rewrite *gomock.Call.Panic
+func (c *MockTypedStoreSearchCall) Panic(v any) *MockTypedStoreSearchCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
@@ -0,0 +1,28 @@
+package compose
+
+//go:generate mockgen -package compose -destination mock.go -compose ReadCloserStore=io.Reader,io.Closer,go.uber.org/mock/mockgen/internal/tests/compose.Store
+
+import "io"
+
+// Store persists values by key.
+type Store interface {
+	Get(key string) ([]byte, error)
+	Close() error
+}
+
+// ReadCloserStore is everything Load needs.
+type ReadCloserStore interface {
+	io.ReadCloser
+	Store
+}
+
+// Load reads at most n bytes from s, caches them under key and closes s.
+func Load(s ReadCloserStore, key string, n int) ([]byte, error) {
+	defer s.Close()
+	if b, err := s.Get(key); err == nil {
+		return b, nil
+	}
+	b := make([]byte, n)
+	n, err := s.Read(b)
+	return b[:n], err
+}
@@ -0,0 +1,32 @@
+package compose
+
+import (
+	"io"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestLoad(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s := NewMockReadCloserStore(ctrl)
+
+	var _ io.ReadCloser = s
+	var _ Store = s
+
+	gomock.InOrder(
+		s.EXPECT().Get("key").Return(nil, io.EOF),
+		s.EXPECT().Read(gomock.Len(4)).DoAndReturn(func(p []byte) (int, error) {
+			return copy(p, "data"), nil
+		}),
+		s.EXPECT().Close().Return(nil),
+	)
+
+	b, err := Load(s, "key", 4)
+	if err != nil {
+		t.Fatalf("Load() returned unexpected error: %v", err)
+	}
+	if string(b) != "data" {
+		t.Fatalf("Load() = %q, want %q", b, "data")
+	}
+}
@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: composed interfaces (ReadCloserStore=io.Reader,io.Closer,go.uber.org/mock/mockgen/internal/tests/compose.Store)
+//
+// Generated by this command:
+//
+//	mockgen -package compose -destination mock.go -compose ReadCloserStore=io.Reader,io.Closer,go.uber.org/mock/mockgen/internal/tests/compose.Store
+//
+
+// Package compose is a generated GoMock package.
+package compose
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReadCloserStore is a mock of ReadCloserStore interface.
+type MockReadCloserStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadCloserStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockReadCloserStoreMockRecorder is the mock recorder for MockReadCloserStore.
+type MockReadCloserStoreMockRecorder struct {
+	mock *MockReadCloserStore
+}
+
+// NewMockReadCloserStore creates a new mock instance.
+func NewMockReadCloserStore(ctrl *gomock.Controller) *MockReadCloserStore {
+	mock := &MockReadCloserStore{ctrl: ctrl}
+	mock.recorder = &MockReadCloserStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadCloserStore) EXPECT() *MockReadCloserStoreMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockReadCloserStore) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockReadCloserStoreMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockReadCloserStore)(nil).Close))
+}
+
+// Get mocks base method.
+func (m *MockReadCloserStore) Get(key string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReadCloserStoreMockRecorder) Get(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReadCloserStore)(nil).Get), key)
+}
+
+// Read mocks base method.
+func (m *MockReadCloserStore) Read(p []byte) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", p)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockReadCloserStoreMockRecorder) Read(p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockReadCloserStore)(nil).Read), p)
+}
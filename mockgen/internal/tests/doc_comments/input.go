@@ -0,0 +1,19 @@
+package doc_comments
+
+//go:generate mockgen -package doc_comments -destination source_mock.go -source input.go -mock_names Store=MockSourceStore -write_doc_comments
+//go:generate mockgen -package doc_comments -destination package_mock.go -mock_names Store=MockPackageStore -write_doc_comments . Store
+
+import "io"
+
+// Store persists blobs.
+//
+// Implementations must be safe for concurrent use.
+type Store interface {
+	io.Closer
+
+	// Get returns the blob stored under key.
+	Get(key string) ([]byte, error)
+	// Put stores blob under key, replacing any previous blob.
+	Put(key string, blob []byte) error
+	Len() int // not a doc comment
+}
@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: go.uber.org/mock/mockgen/internal/tests/doc_comments (interfaces: Store)
+//
+// Generated by this command:
+//
+//	mockgen -package doc_comments -destination package_mock.go -mock_names Store=MockPackageStore -write_doc_comments . Store
+//
+
+// Package doc_comments is a generated GoMock package.
+package doc_comments
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPackageStore is a mock of Store interface.
+//
+// Store persists blobs.
+//
+// Implementations must be safe for concurrent use.
+type MockPackageStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockPackageStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockPackageStoreMockRecorder is the mock recorder for MockPackageStore.
+type MockPackageStoreMockRecorder struct {
+	mock *MockPackageStore
+}
+
+// NewMockPackageStore creates a new mock instance.
+func NewMockPackageStore(ctrl *gomock.Controller) *MockPackageStore {
+	mock := &MockPackageStore{ctrl: ctrl}
+	mock.recorder = &MockPackageStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPackageStore) EXPECT() *MockPackageStoreMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockPackageStore) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockPackageStoreMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPackageStore)(nil).Close))
+}
+
+// Get mocks base method.
+//
+// Get returns the blob stored under key.
+func (m *MockPackageStore) Get(key string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPackageStoreMockRecorder) Get(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPackageStore)(nil).Get), key)
+}
+
+// Len mocks base method.
+func (m *MockPackageStore) Len() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Len")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Len indicates an expected call of Len.
+func (mr *MockPackageStoreMockRecorder) Len() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Len", reflect.TypeOf((*MockPackageStore)(nil).Len))
+}
+
+// Put mocks base method.
+//
+// Put stores blob under key, replacing any previous blob.
+func (m *MockPackageStore) Put(key string, blob []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", key, blob)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockPackageStoreMockRecorder) Put(key, blob any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockPackageStore)(nil).Put), key, blob)
+}
@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+//
+// Generated by this command:
+//
+//	mockgen -package doc_comments -destination source_mock.go -source input.go -mock_names Store=MockSourceStore -write_doc_comments
+//
+
+// Package doc_comments is a generated GoMock package.
+package doc_comments
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSourceStore is a mock of Store interface.
+//
+// Store persists blobs.
+//
+// Implementations must be safe for concurrent use.
+type MockSourceStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockSourceStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockSourceStoreMockRecorder is the mock recorder for MockSourceStore.
+type MockSourceStoreMockRecorder struct {
+	mock *MockSourceStore
+}
+
+// NewMockSourceStore creates a new mock instance.
+func NewMockSourceStore(ctrl *gomock.Controller) *MockSourceStore {
+	mock := &MockSourceStore{ctrl: ctrl}
+	mock.recorder = &MockSourceStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSourceStore) EXPECT() *MockSourceStoreMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockSourceStore) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockSourceStoreMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockSourceStore)(nil).Close))
+}
+
+// Get mocks base method.
+//
+// Get returns the blob stored under key.
+func (m *MockSourceStore) Get(key string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSourceStoreMockRecorder) Get(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSourceStore)(nil).Get), key)
+}
+
+// Len mocks base method.
+func (m *MockSourceStore) Len() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Len")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Len indicates an expected call of Len.
+func (mr *MockSourceStoreMockRecorder) Len() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Len", reflect.TypeOf((*MockSourceStore)(nil).Len))
+}
+
+// Put mocks base method.
+//
+// Put stores blob under key, replacing any previous blob.
+func (m *MockSourceStore) Put(key string, blob []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", key, blob)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockSourceStoreMockRecorder) Put(key, blob any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockSourceStore)(nil).Put), key, blob)
+}
@@ -0,0 +1,53 @@
+package extract_interface
+
+//go:generate mockgen -package extract_interface -destination mock.go -extract_interfaces . DB,Cache
+
+import (
+	"context"
+	"sync"
+)
+
+// conn is embedded in DB; its exported methods are promoted.
+type conn struct{}
+
+// Ping checks the connection.
+func (conn) Ping(ctx context.Context) error { return nil }
+
+// DB is a concrete database handle that code depends on directly.
+type DB struct {
+	conn
+	mu   sync.Mutex
+	rows map[string][]string
+}
+
+// Query returns the rows of table.
+func (db *DB) Query(ctx context.Context, table string, limit int) ([]string, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	rows := db.rows[table]
+	if len(rows) > limit {
+		rows = rows[:limit]
+	}
+	return rows, nil
+}
+
+// Exec executes stmt with args.
+func (db *DB) Exec(ctx context.Context, stmt string, args ...any) (int64, error) {
+	return 0, nil
+}
+
+// Close closes the handle.
+func (db *DB) Close() {}
+
+func (db *DB) reset() { db.rows = nil }
+
+// Cache is a generic concrete type.
+type Cache[K comparable, V any] struct {
+	entries map[K]V
+}
+
+// Get returns the entry stored under key.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	v, ok := c.entries[key]
+	return v, ok
+}
@@ -0,0 +1,65 @@
+package extract_interface
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+// countRows is legacy code migrated from *DB to the extracted interface.
+func countRows(ctx context.Context, db DBInterface, table string) (int, error) {
+	if err := db.Ping(ctx); err != nil {
+		return 0, err
+	}
+	rows, err := db.Query(ctx, table, 100)
+	return len(rows), err
+}
+
+func TestCountRows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	db := NewMockDB(ctrl)
+	db.EXPECT().Ping(ctx).Return(nil)
+	db.EXPECT().Query(ctx, "users", 100).Return([]string{"alice", "bob"}, nil)
+
+	n, err := countRows(ctx, db, "users")
+	if err != nil {
+		t.Fatalf("countRows() returned unexpected error: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("countRows() = %d, want 2", n)
+	}
+
+	// The real type satisfies the extracted interface too.
+	if _, err := countRows(ctx, &DB{}, "users"); err != nil {
+		t.Fatalf("countRows() returned unexpected error: %v", err)
+	}
+}
+
+func TestCountRowsPingError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	errDown := errors.New("down")
+	db := NewMockDB(ctrl)
+	db.EXPECT().Ping(ctx).Return(errDown)
+
+	if _, err := countRows(ctx, db, "users"); !errors.Is(err, errDown) {
+		t.Fatalf("countRows() error = %v, want %v", err, errDown)
+	}
+}
+
+func TestGenericCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	var cache CacheInterface[string, int] = NewMockCache[string, int](ctrl)
+	cache.(*MockCache[string, int]).EXPECT().Get("a").Return(1, true)
+	if v, ok := cache.Get("a"); !ok || v != 1 {
+		t.Fatalf("Get() = %v, %v, want 1, true", v, ok)
+	}
+
+	var _ CacheInterface[string, int] = &Cache[string, int]{}
+}
@@ -0,0 +1,192 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+//
+// Generated by this command:
+//
+//	mockgen -package fake -source=input.go -destination=fake.go -style=fake
+//
+
+// Package fake is a generated GoMock package.
+package fake
+
+import (
+	sync "sync"
+)
+
+// FakeStore is a fake implementation of Store interface.
+type FakeStore struct {
+	mu sync.Mutex
+
+	closeStub      func()
+	closeCallCount int
+
+	getStub        func(int) (string, error)
+	getArgsForCall []struct{ arg1 int }
+	getReturns     struct {
+		result1 string
+		result2 error
+	}
+
+	lenStub      func() int
+	lenCallCount int
+	lenReturns   struct{ result1 int }
+
+	putStub        func(int, ...string) error
+	putArgsForCall []struct {
+		arg1 int
+		arg2 []string
+	}
+	putReturns struct{ result1 error }
+}
+
+// Close implements the faked method.
+func (fake *FakeStore) Close() {
+	fake.mu.Lock()
+	fake.closeCallCount++
+	stub := fake.closeStub
+	fake.mu.Unlock()
+	if stub != nil {
+		stub()
+	}
+}
+
+// CloseCallCount returns the number of calls to Close.
+func (fake *FakeStore) CloseCallCount() int {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	return fake.closeCallCount
+}
+
+// CloseCalls makes Close delegate to stub.
+func (fake *FakeStore) CloseCalls(stub func()) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	fake.closeStub = stub
+}
+
+// Get implements the faked method.
+func (fake *FakeStore) Get(id int) (string, error) {
+	fake.mu.Lock()
+	fake.getArgsForCall = append(fake.getArgsForCall, struct{ arg1 int }{id})
+	stub := fake.getStub
+	returns := fake.getReturns
+	fake.mu.Unlock()
+	if stub != nil {
+		return stub(id)
+	}
+	return returns.result1, returns.result2
+}
+
+// GetCallCount returns the number of calls to Get.
+func (fake *FakeStore) GetCallCount() int {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	return len(fake.getArgsForCall)
+}
+
+// GetCalls makes Get delegate to stub, which takes precedence over GetReturns.
+func (fake *FakeStore) GetCalls(stub func(int) (string, error)) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	fake.getStub = stub
+}
+
+// GetArgsForCall returns the arguments of the i-th call to Get.
+func (fake *FakeStore) GetArgsForCall(i int) int {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	args := fake.getArgsForCall[i]
+	return args.arg1
+}
+
+// GetReturns makes Get return the given values.
+func (fake *FakeStore) GetReturns(result1 string, result2 error) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	fake.getStub = nil
+	fake.getReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+// Len implements the faked method.
+func (fake *FakeStore) Len() int {
+	fake.mu.Lock()
+	fake.lenCallCount++
+	stub := fake.lenStub
+	returns := fake.lenReturns
+	fake.mu.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	return returns.result1
+}
+
+// LenCallCount returns the number of calls to Len.
+func (fake *FakeStore) LenCallCount() int {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	return fake.lenCallCount
+}
+
+// LenCalls makes Len delegate to stub, which takes precedence over LenReturns.
+func (fake *FakeStore) LenCalls(stub func() int) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	fake.lenStub = stub
+}
+
+// LenReturns makes Len return the given values.
+func (fake *FakeStore) LenReturns(result1 int) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	fake.lenStub = nil
+	fake.lenReturns = struct{ result1 int }{result1}
+}
+
+// Put implements the faked method.
+func (fake *FakeStore) Put(id int, names ...string) error {
+	fake.mu.Lock()
+	fake.putArgsForCall = append(fake.putArgsForCall, struct {
+		arg1 int
+		arg2 []string
+	}{id, names})
+	stub := fake.putStub
+	returns := fake.putReturns
+	fake.mu.Unlock()
+	if stub != nil {
+		return stub(id, names...)
+	}
+	return returns.result1
+}
+
+// PutCallCount returns the number of calls to Put.
+func (fake *FakeStore) PutCallCount() int {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	return len(fake.putArgsForCall)
+}
+
+// PutCalls makes Put delegate to stub, which takes precedence over PutReturns.
+func (fake *FakeStore) PutCalls(stub func(int, ...string) error) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	fake.putStub = stub
+}
+
+// PutArgsForCall returns the arguments of the i-th call to Put.
+func (fake *FakeStore) PutArgsForCall(i int) (int, []string) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	args := fake.putArgsForCall[i]
+	return args.arg1, args.arg2
+}
+
+// PutReturns makes Put return the given values.
+func (fake *FakeStore) PutReturns(result1 error) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	fake.putStub = nil
+	fake.putReturns = struct{ result1 error }{result1}
+}
@@ -0,0 +1,23 @@
+package fake
+
+//go:generate mockgen -package fake -source=input.go -destination=fake.go -style=fake
+
+// Store persists users.
+type Store interface {
+	Get(id int) (string, error)
+	Put(id int, names ...string) error
+	Close()
+	Len() int
+}
+
+// Rename renames the user id in s and returns its previous name.
+func Rename(s Store, id int, name string) (string, error) {
+	old, err := s.Get(id)
+	if err != nil {
+		return "", err
+	}
+	if err := s.Put(id, name, old); err != nil {
+		return "", err
+	}
+	return old, nil
+}
@@ -0,0 +1,66 @@
+package fake
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRename(t *testing.T) {
+	store := &FakeStore{}
+	store.GetReturns("alice", nil)
+
+	old, err := Rename(store, 1, "bob")
+	if err != nil {
+		t.Fatalf("Rename() returned unexpected error: %v", err)
+	}
+	if old != "alice" {
+		t.Fatalf("Rename() = %q, want %q", old, "alice")
+	}
+
+	if got := store.GetCallCount(); got != 1 {
+		t.Fatalf("GetCallCount() = %d, want 1", got)
+	}
+	if id := store.GetArgsForCall(0); id != 1 {
+		t.Fatalf("GetArgsForCall(0) = %d, want 1", id)
+	}
+	if got := store.PutCallCount(); got != 1 {
+		t.Fatalf("PutCallCount() = %d, want 1", got)
+	}
+	id, names := store.PutArgsForCall(0)
+	if id != 1 || len(names) != 2 || names[0] != "bob" || names[1] != "alice" {
+		t.Fatalf("PutArgsForCall(0) = %d, %q, want 1, [bob alice]", id, names)
+	}
+}
+
+func TestRenameError(t *testing.T) {
+	errNotFound := errors.New("not found")
+	store := &FakeStore{}
+	store.GetCalls(func(id int) (string, error) {
+		return "", errNotFound
+	})
+
+	if _, err := Rename(store, 1, "bob"); !errors.Is(err, errNotFound) {
+		t.Fatalf("Rename() error = %v, want %v", err, errNotFound)
+	}
+	if got := store.PutCallCount(); got != 0 {
+		t.Fatalf("PutCallCount() = %d, want 0", got)
+	}
+}
+
+func TestZeroValue(t *testing.T) {
+	store := &FakeStore{}
+	if n := store.Len(); n != 0 {
+		t.Fatalf("Len() = %d, want 0", n)
+	}
+	store.LenReturns(3)
+	if n := store.Len(); n != 3 {
+		t.Fatalf("Len() = %d, want 3", n)
+	}
+
+	closed := false
+	store.CloseCalls(func() { closed = true })
+	store.Close()
+	if !closed || store.CloseCallCount() != 1 {
+		t.Fatalf("Close() did not call the stub")
+	}
+}
@@ -0,0 +1,32 @@
+package func_type
+
+//go:generate mockgen -package func_type -source=input.go -destination=mock.go -typed Handler,Visitor,Server
+
+import "context"
+
+// Handler handles a single request.
+type Handler func(ctx context.Context, req string) (string, error)
+
+// Visitor is called for every visited element.
+type Visitor[T any] func(elem T, path ...string) bool
+
+// Server dispatches requests to handlers.
+type Server interface {
+	Register(name string, h Handler)
+}
+
+// Serve registers h under name and invokes it with req.
+func Serve(ctx context.Context, s Server, name string, h Handler, req string) (string, error) {
+	s.Register(name, h)
+	return h(ctx, req)
+}
+
+// Walk calls visit for every element of elems until it returns false.
+func Walk[T any](elems []T, visit Visitor[T]) int {
+	for i, e := range elems {
+		if !visit(e, "root") {
+			return i
+		}
+	}
+	return len(elems)
+}
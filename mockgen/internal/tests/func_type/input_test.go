@@ -0,0 +1,40 @@
+package func_type
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestServe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	server := NewMockServer(ctrl)
+	handler := NewMockHandler(ctrl)
+	server.EXPECT().Register("echo", gomock.Any())
+	handler.EXPECT().Call(ctx, "ping").Return("pong", nil)
+
+	resp, err := Serve(ctx, server, "echo", handler.Func(), "ping")
+	if err != nil {
+		t.Fatalf("Serve() returned unexpected error: %v", err)
+	}
+	if resp != "pong" {
+		t.Fatalf("Serve() = %q, want %q", resp, "pong")
+	}
+}
+
+func TestWalk(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	visitor := NewMockVisitor[int](ctrl)
+	gomock.InOrder(
+		visitor.EXPECT().Call(1, "root").Return(true),
+		visitor.EXPECT().Call(2, gomock.Any()).Return(false),
+	)
+
+	if n := Walk([]int{1, 2, 3}, visitor.Func()); n != 1 {
+		t.Fatalf("Walk() = %d, want 1", n)
+	}
+}
@@ -0,0 +1,241 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+//
+// Generated by this command:
+//
+//	mockgen -package func_type -source=input.go -destination=mock.go -typed Handler,Visitor,Server
+//
+
+// Package func_type is a generated GoMock package.
+package func_type
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockServer is a mock of Server interface.
+type MockServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockServerMockRecorder
+	isgomock struct{}
+}
+
+// MockServerMockRecorder is the mock recorder for MockServer.
+type MockServerMockRecorder struct {
+	mock *MockServer
+}
+
+// NewMockServer creates a new mock instance.
+func NewMockServer(ctrl *gomock.Controller) *MockServer {
+	mock := &MockServer{ctrl: ctrl}
+	mock.recorder = &MockServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServer) EXPECT() *MockServerMockRecorder {
+	return m.recorder
+}
+
+// Register mocks base method.
+func (m *MockServer) Register(name string, h Handler) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Register", name, h)
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockServerMockRecorder) Register(name, h any) *MockServerRegisterCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockServer)(nil).Register), name, h)
+	return &MockServerRegisterCall{Call: call}
+}
+
+// MockServerRegisterCall wrap *gomock.Call
+type MockServerRegisterCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServerRegisterCall) Return() *MockServerRegisterCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServerRegisterCall) Do(f func(string, Handler)) *MockServerRegisterCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServerRegisterCall) DoAndReturn(f func(string, Handler)) *MockServerRegisterCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Panic rewrite *gomock.Call.Panic
+func (c *MockServerRegisterCall) Panic(v any) *MockServerRegisterCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
+// MockHandler is a mock of Handler function type.
+type MockHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockHandlerMockRecorder is the mock recorder for MockHandler.
+type MockHandlerMockRecorder struct {
+	mock *MockHandler
+}
+
+// NewMockHandler creates a new mock instance.
+func NewMockHandler(ctrl *gomock.Controller) *MockHandler {
+	mock := &MockHandler{ctrl: ctrl}
+	mock.recorder = &MockHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHandler) EXPECT() *MockHandlerMockRecorder {
+	return m.recorder
+}
+
+// Func returns a Handler that forwards its calls to MockHandler.
+func (m *MockHandler) Func() Handler {
+	return m.Call
+}
+
+// Call mocks base method.
+func (m *MockHandler) Call(ctx context.Context, req string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Call", ctx, req)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Call indicates an expected call of Call.
+func (mr *MockHandlerMockRecorder) Call(ctx, req any) *MockHandlerCallCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Call", reflect.TypeOf((*MockHandler)(nil).Call), ctx, req)
+	return &MockHandlerCallCall{Call: call}
+}
+
+// MockHandlerCallCall wrap *gomock.Call
+type MockHandlerCallCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockHandlerCallCall) Return(arg0 string, arg1 error) *MockHandlerCallCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockHandlerCallCall) Do(f func(context.Context, string) (string, error)) *MockHandlerCallCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockHandlerCallCall) DoAndReturn(f func(context.Context, string) (string, error)) *MockHandlerCallCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Panic rewrite *gomock.Call.Panic
+func (c *MockHandlerCallCall) Panic(v any) *MockHandlerCallCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
+// ReturnError rewrite *gomock.Call.ReturnError
+func (c *MockHandlerCallCall) ReturnError(err error) *MockHandlerCallCall {
+	c.Call = c.Call.ReturnError(err)
+	return c
+}
+
+// MockVisitor is a mock of Visitor function type.
+type MockVisitor[T any] struct {
+	ctrl     *gomock.Controller
+	recorder *MockVisitorMockRecorder[T]
+	isgomock struct{}
+}
+
+// MockVisitorMockRecorder is the mock recorder for MockVisitor.
+type MockVisitorMockRecorder[T any] struct {
+	mock *MockVisitor[T]
+}
+
+// NewMockVisitor creates a new mock instance.
+func NewMockVisitor[T any](ctrl *gomock.Controller) *MockVisitor[T] {
+	mock := &MockVisitor[T]{ctrl: ctrl}
+	mock.recorder = &MockVisitorMockRecorder[T]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVisitor[T]) EXPECT() *MockVisitorMockRecorder[T] {
+	return m.recorder
+}
+
+// Func returns a Visitor that forwards its calls to MockVisitor.
+func (m *MockVisitor[T]) Func() Visitor[T] {
+	return m.Call
+}
+
+// Call mocks base method.
+func (m *MockVisitor[T]) Call(elem T, path ...string) bool {
+	m.ctrl.T.Helper()
+	varargs := []any{elem}
+	for _, a := range path {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Call", varargs...)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Call indicates an expected call of Call.
+func (mr *MockVisitorMockRecorder[T]) Call(elem any, path ...any) *MockVisitorCallCall[T] {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{elem}, path...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Call", reflect.TypeOf((*MockVisitor[T])(nil).Call), varargs...)
+	return &MockVisitorCallCall[T]{Call: call}
+}
+
+// MockVisitorCallCall wrap *gomock.Call
+type MockVisitorCallCall[T any] struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockVisitorCallCall[T]) Return(arg0 bool) *MockVisitorCallCall[T] {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockVisitorCallCall[T]) Do(f func(T, ...string) bool) *MockVisitorCallCall[T] {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockVisitorCallCall[T]) DoAndReturn(f func(T, ...string) bool) *MockVisitorCallCall[T] {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Panic rewrite *gomock.Call.Panic
+func (c *MockVisitorCallCall[T]) Panic(v any) *MockVisitorCallCall[T] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
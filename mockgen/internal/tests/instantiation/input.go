@@ -0,0 +1,23 @@
+package instantiation
+
+//go:generate mockgen -package instantiation -destination source_mock.go -source input.go -mock_names UserRepo=MockSourceUserRepo,StringIntCache=MockSourceStringIntCache Repo[go.uber.org/mock/mockgen/internal/tests/instantiation/models.User],Cache[string,int]
+//go:generate mockgen -package instantiation -destination package_mock.go . Repo[go.uber.org/mock/mockgen/internal/tests/instantiation/models.User],Cache[string,int],Handler[*Request,[]byte]
+
+import "context"
+
+type Repo[T any] interface {
+	Get(ctx context.Context, id int) (T, error)
+	List(ctx context.Context, filter func(T) bool) ([]T, error)
+	Put(ctx context.Context, items ...T) error
+}
+
+type Cache[K comparable, V any] interface {
+	Get(key K) (V, bool)
+	Set(key K, value V)
+}
+
+type Handler[Req, Resp any] func(ctx context.Context, req Req) (Resp, error)
+
+type Request struct {
+	Path string
+}
@@ -0,0 +1,44 @@
+package instantiation
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"go.uber.org/mock/mockgen/internal/tests/instantiation/models"
+)
+
+var (
+	_ Repo[models.User]  = (*MockUserRepo)(nil)
+	_ Repo[models.User]  = (*MockSourceUserRepo)(nil)
+	_ Cache[string, int] = (*MockStringIntCache)(nil)
+	_ Cache[string, int] = (*MockSourceStringIntCache)(nil)
+)
+
+func TestMockUserRepo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := NewMockUserRepo(ctrl)
+	alice := models.User{ID: 1, Name: "alice"}
+	repo.EXPECT().Get(gomock.Any(), 1).Return(alice, nil)
+
+	var r Repo[models.User] = repo
+	got, err := r.Get(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Get() returned unexpected error: %v", err)
+	}
+	if got != alice {
+		t.Fatalf("Get() = %v, want %v", got, alice)
+	}
+}
+
+func TestMockRequestByteSliceHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	handler := NewMockRequestByteSliceHandler(ctrl)
+	handler.EXPECT().Call(gomock.Any(), &Request{Path: "/"}).Return([]byte("ok"), nil)
+
+	var h Handler[*Request, []byte] = handler.Func()
+	got, err := h(context.Background(), &Request{Path: "/"})
+	if err != nil || string(got) != "ok" {
+		t.Fatalf("h() = %q, %v, want %q, nil", got, err, "ok")
+	}
+}
@@ -0,0 +1,6 @@
+package models
+
+type User struct {
+	ID   int
+	Name string
+}
@@ -0,0 +1,186 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: go.uber.org/mock/mockgen/internal/tests/instantiation (interfaces: Repo[go.uber.org/mock/mockgen/internal/tests/instantiation/models.User],Cache[string,int],Handler[*Request,[]byte])
+//
+// Generated by this command:
+//
+//	mockgen -package instantiation -destination package_mock.go . Repo[go.uber.org/mock/mockgen/internal/tests/instantiation/models.User],Cache[string,int],Handler[*Request,[]byte]
+//
+
+// Package instantiation is a generated GoMock package.
+package instantiation
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	models "go.uber.org/mock/mockgen/internal/tests/instantiation/models"
+)
+
+// MockUserRepo is a mock of Repo[models.User] interface.
+type MockUserRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockUserRepoMockRecorder is the mock recorder for MockUserRepo.
+type MockUserRepoMockRecorder struct {
+	mock *MockUserRepo
+}
+
+// NewMockUserRepo creates a new mock instance.
+func NewMockUserRepo(ctrl *gomock.Controller) *MockUserRepo {
+	mock := &MockUserRepo{ctrl: ctrl}
+	mock.recorder = &MockUserRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserRepo) EXPECT() *MockUserRepoMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockUserRepo) Get(ctx context.Context, id int) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockUserRepoMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUserRepo)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockUserRepo) List(ctx context.Context, filter func(models.User) bool) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockUserRepoMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserRepo)(nil).List), ctx, filter)
+}
+
+// Put mocks base method.
+func (m *MockUserRepo) Put(ctx context.Context, items ...models.User) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range items {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Put", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockUserRepoMockRecorder) Put(ctx any, items ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, items...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockUserRepo)(nil).Put), varargs...)
+}
+
+// MockStringIntCache is a mock of Cache[string, int] interface.
+type MockStringIntCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockStringIntCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockStringIntCacheMockRecorder is the mock recorder for MockStringIntCache.
+type MockStringIntCacheMockRecorder struct {
+	mock *MockStringIntCache
+}
+
+// NewMockStringIntCache creates a new mock instance.
+func NewMockStringIntCache(ctrl *gomock.Controller) *MockStringIntCache {
+	mock := &MockStringIntCache{ctrl: ctrl}
+	mock.recorder = &MockStringIntCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStringIntCache) EXPECT() *MockStringIntCacheMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockStringIntCache) Get(key string) (int, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockStringIntCacheMockRecorder) Get(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStringIntCache)(nil).Get), key)
+}
+
+// Set mocks base method.
+func (m *MockStringIntCache) Set(key string, value int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Set", key, value)
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockStringIntCacheMockRecorder) Set(key, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockStringIntCache)(nil).Set), key, value)
+}
+
+// MockRequestByteSliceHandler is a mock of Handler[*Request, []byte] function type.
+type MockRequestByteSliceHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockRequestByteSliceHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockRequestByteSliceHandlerMockRecorder is the mock recorder for MockRequestByteSliceHandler.
+type MockRequestByteSliceHandlerMockRecorder struct {
+	mock *MockRequestByteSliceHandler
+}
+
+// NewMockRequestByteSliceHandler creates a new mock instance.
+func NewMockRequestByteSliceHandler(ctrl *gomock.Controller) *MockRequestByteSliceHandler {
+	mock := &MockRequestByteSliceHandler{ctrl: ctrl}
+	mock.recorder = &MockRequestByteSliceHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRequestByteSliceHandler) EXPECT() *MockRequestByteSliceHandlerMockRecorder {
+	return m.recorder
+}
+
+// Func returns a Handler[*Request, []byte] that forwards its calls to MockRequestByteSliceHandler.
+func (m *MockRequestByteSliceHandler) Func() Handler[*Request, []byte] {
+	return m.Call
+}
+
+// Call mocks base method.
+func (m *MockRequestByteSliceHandler) Call(ctx context.Context, req *Request) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Call", ctx, req)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Call indicates an expected call of Call.
+func (mr *MockRequestByteSliceHandlerMockRecorder) Call(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Call", reflect.TypeOf((*MockRequestByteSliceHandler)(nil).Call), ctx, req)
+}
@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+//
+// Generated by this command:
+//
+//	mockgen -package instantiation -destination source_mock.go -source input.go -mock_names UserRepo=MockSourceUserRepo,StringIntCache=MockSourceStringIntCache Repo[go.uber.org/mock/mockgen/internal/tests/instantiation/models.User],Cache[string,int]
+//
+
+// Package instantiation is a generated GoMock package.
+package instantiation
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	models "go.uber.org/mock/mockgen/internal/tests/instantiation/models"
+)
+
+// MockSourceUserRepo is a mock of Repo[models.User] interface.
+type MockSourceUserRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSourceUserRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockSourceUserRepoMockRecorder is the mock recorder for MockSourceUserRepo.
+type MockSourceUserRepoMockRecorder struct {
+	mock *MockSourceUserRepo
+}
+
+// NewMockSourceUserRepo creates a new mock instance.
+func NewMockSourceUserRepo(ctrl *gomock.Controller) *MockSourceUserRepo {
+	mock := &MockSourceUserRepo{ctrl: ctrl}
+	mock.recorder = &MockSourceUserRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSourceUserRepo) EXPECT() *MockSourceUserRepoMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockSourceUserRepo) Get(ctx context.Context, id int) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSourceUserRepoMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSourceUserRepo)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockSourceUserRepo) List(ctx context.Context, filter func(models.User) bool) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSourceUserRepoMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSourceUserRepo)(nil).List), ctx, filter)
+}
+
+// Put mocks base method.
+func (m *MockSourceUserRepo) Put(ctx context.Context, items ...models.User) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range items {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Put", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockSourceUserRepoMockRecorder) Put(ctx any, items ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, items...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockSourceUserRepo)(nil).Put), varargs...)
+}
+
+// MockSourceStringIntCache is a mock of Cache[string, int] interface.
+type MockSourceStringIntCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockSourceStringIntCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockSourceStringIntCacheMockRecorder is the mock recorder for MockSourceStringIntCache.
+type MockSourceStringIntCacheMockRecorder struct {
+	mock *MockSourceStringIntCache
+}
+
+// NewMockSourceStringIntCache creates a new mock instance.
+func NewMockSourceStringIntCache(ctrl *gomock.Controller) *MockSourceStringIntCache {
+	mock := &MockSourceStringIntCache{ctrl: ctrl}
+	mock.recorder = &MockSourceStringIntCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSourceStringIntCache) EXPECT() *MockSourceStringIntCacheMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockSourceStringIntCache) Get(key string) (int, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSourceStringIntCacheMockRecorder) Get(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSourceStringIntCache)(nil).Get), key)
+}
+
+// Set mocks base method.
+func (m *MockSourceStringIntCache) Set(key string, value int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Set", key, value)
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockSourceStringIntCacheMockRecorder) Set(key, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockSourceStringIntCache)(nil).Set), key, value)
+}
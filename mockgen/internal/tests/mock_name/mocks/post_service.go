@@ -79,3 +79,15 @@ func (c *PostServiceMockCreateCall) DoAndReturn(f func(string, string, *user.Use
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// Panic rewrite *gomock.Call.Panic
+func (c *PostServiceMockCreateCall) Panic(v any) *PostServiceMockCreateCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
+// ReturnError rewrite *gomock.Call.ReturnError
+func (c *PostServiceMockCreateCall) ReturnError(err error) *PostServiceMockCreateCall {
+	c.Call = c.Call.ReturnError(err)
+	return c
+}
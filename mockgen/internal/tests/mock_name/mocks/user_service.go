@@ -78,3 +78,15 @@ func (c *UserServiceMockCreateCall) DoAndReturn(f func(string) (*user.User, erro
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// Panic rewrite *gomock.Call.Panic
+func (c *UserServiceMockCreateCall) Panic(v any) *UserServiceMockCreateCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
+// ReturnError rewrite *gomock.Call.ReturnError
+func (c *UserServiceMockCreateCall) ReturnError(err error) *UserServiceMockCreateCall {
+	c.Call = c.Call.ReturnError(err)
+	return c
+}
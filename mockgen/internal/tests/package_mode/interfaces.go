@@ -56,6 +56,8 @@ type Work struct {
 	Name string
 }
 
+type Feeder func(eater Eater, foods ...Food) (int, error)
+
 type Counter interface {
 	int
 }
@@ -81,6 +81,12 @@ func (c *MockFoodCaloriesCall) DoAndReturn(f func() int) *MockFoodCaloriesCall {
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockFoodCaloriesCall) Panic(v any) *MockFoodCaloriesCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // MockEater is a mock of Eater interface.
 type MockEater struct {
 	ctrl     *gomock.Controller
@@ -145,6 +151,12 @@ func (c *MockEaterEatCall) DoAndReturn(f func(...package_mode.Food)) *MockEaterE
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockEaterEatCall) Panic(v any) *MockEaterEatCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // MockAnimal is a mock of Animal interface.
 type MockAnimal struct {
 	ctrl     *gomock.Controller
@@ -205,6 +217,12 @@ func (c *MockAnimalBreatheCall) DoAndReturn(f func()) *MockAnimalBreatheCall {
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockAnimalBreatheCall) Panic(v any) *MockAnimalBreatheCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Eat mocks base method.
 func (m *MockAnimal) Eat(foods ...package_mode.Food) {
 	m.ctrl.T.Helper()
@@ -245,6 +263,12 @@ func (c *MockAnimalEatCall) DoAndReturn(f func(...package_mode.Food)) *MockAnima
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockAnimalEatCall) Panic(v any) *MockAnimalEatCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Sleep mocks base method.
 func (m *MockAnimal) Sleep(duration time.Duration) {
 	m.ctrl.T.Helper()
@@ -281,6 +305,12 @@ func (c *MockAnimalSleepCall) DoAndReturn(f func(time.Duration)) *MockAnimalSlee
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockAnimalSleepCall) Panic(v any) *MockAnimalSleepCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // MockHuman is a mock of Human interface.
 type MockHuman struct {
 	ctrl     *gomock.Controller
@@ -341,6 +371,12 @@ func (c *MockHumanBreatheCall) DoAndReturn(f func()) *MockHumanBreatheCall {
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockHumanBreatheCall) Panic(v any) *MockHumanBreatheCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Eat mocks base method.
 func (m *MockHuman) Eat(foods ...package_mode.Food) {
 	m.ctrl.T.Helper()
@@ -381,6 +417,12 @@ func (c *MockHumanEatCall) DoAndReturn(f func(...package_mode.Food)) *MockHumanE
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockHumanEatCall) Panic(v any) *MockHumanEatCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Sleep mocks base method.
 func (m *MockHuman) Sleep(duration time.Duration) {
 	m.ctrl.T.Helper()
@@ -417,6 +459,12 @@ func (c *MockHumanSleepCall) DoAndReturn(f func(time.Duration)) *MockHumanSleepC
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockHumanSleepCall) Panic(v any) *MockHumanSleepCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // MockPrimate is a mock of Primate interface.
 type MockPrimate struct {
 	ctrl     *gomock.Controller
@@ -477,6 +525,12 @@ func (c *MockPrimateBreatheCall) DoAndReturn(f func()) *MockPrimateBreatheCall {
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockPrimateBreatheCall) Panic(v any) *MockPrimateBreatheCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Eat mocks base method.
 func (m *MockPrimate) Eat(foods ...package_mode.Food) {
 	m.ctrl.T.Helper()
@@ -517,6 +571,12 @@ func (c *MockPrimateEatCall) DoAndReturn(f func(...package_mode.Food)) *MockPrim
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockPrimateEatCall) Panic(v any) *MockPrimateEatCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Sleep mocks base method.
 func (m *MockPrimate) Sleep(duration time.Duration) {
 	m.ctrl.T.Helper()
@@ -553,6 +613,12 @@ func (c *MockPrimateSleepCall) DoAndReturn(f func(time.Duration)) *MockPrimateSl
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockPrimateSleepCall) Panic(v any) *MockPrimateSleepCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // MockCar is a mock of Car interface.
 type MockCar[FuelType fuel.Fuel] struct {
 	ctrl     *gomock.Controller
@@ -615,6 +681,12 @@ func (c *MockCarBrandCall[FuelType]) DoAndReturn(f func() string) *MockCarBrandC
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockCarBrandCall[FuelType]) Panic(v any) *MockCarBrandCall[FuelType] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // FuelTank mocks base method.
 func (m *MockCar[FuelType]) FuelTank() cars.FuelTank[FuelType] {
 	m.ctrl.T.Helper()
@@ -653,6 +725,12 @@ func (c *MockCarFuelTankCall[FuelType]) DoAndReturn(f func() cars.FuelTank[FuelT
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockCarFuelTankCall[FuelType]) Panic(v any) *MockCarFuelTankCall[FuelType] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Refuel mocks base method.
 func (m *MockCar[FuelType]) Refuel(arg0 FuelType, volume int) error {
 	m.ctrl.T.Helper()
@@ -691,6 +769,18 @@ func (c *MockCarRefuelCall[FuelType]) DoAndReturn(f func(FuelType, int) error) *
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockCarRefuelCall[FuelType]) Panic(v any) *MockCarRefuelCall[FuelType] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
+// ReturnError rewrite *gomock.Call.ReturnError
+func (c *MockCarRefuelCall[FuelType]) ReturnError(err error) *MockCarRefuelCall[FuelType] {
+	c.Call = c.Call.ReturnError(err)
+	return c
+}
+
 // MockDriver is a mock of Driver interface.
 type MockDriver[FuelType fuel.Fuel, CarType package_mode.Car[FuelType]] struct {
 	ctrl     *gomock.Controller
@@ -751,6 +841,12 @@ func (c *MockDriverDriveCall[FuelType, CarType]) DoAndReturn(f func(CarType)) *M
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockDriverDriveCall[FuelType, CarType]) Panic(v any) *MockDriverDriveCall[FuelType, CarType] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Wroom mocks base method.
 func (m *MockDriver[FuelType, CarType]) Wroom() error {
 	m.ctrl.T.Helper()
@@ -789,6 +885,18 @@ func (c *MockDriverWroomCall[FuelType, CarType]) DoAndReturn(f func() error) *Mo
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockDriverWroomCall[FuelType, CarType]) Panic(v any) *MockDriverWroomCall[FuelType, CarType] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
+// ReturnError rewrite *gomock.Call.ReturnError
+func (c *MockDriverWroomCall[FuelType, CarType]) ReturnError(err error) *MockDriverWroomCall[FuelType, CarType] {
+	c.Call = c.Call.ReturnError(err)
+	return c
+}
+
 // MockUrbanResident is a mock of UrbanResident interface.
 type MockUrbanResident struct {
 	ctrl     *gomock.Controller
@@ -849,6 +957,12 @@ func (c *MockUrbanResidentBreatheCall) DoAndReturn(f func()) *MockUrbanResidentB
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockUrbanResidentBreatheCall) Panic(v any) *MockUrbanResidentBreatheCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Do mocks base method.
 func (m *MockUrbanResident) Do(work *package_mode.Work) error {
 	m.ctrl.T.Helper()
@@ -887,6 +1001,18 @@ func (c *MockUrbanResidentDoCall) DoAndReturn(f func(*package_mode.Work) error)
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockUrbanResidentDoCall) Panic(v any) *MockUrbanResidentDoCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
+// ReturnError rewrite *gomock.Call.ReturnError
+func (c *MockUrbanResidentDoCall) ReturnError(err error) *MockUrbanResidentDoCall {
+	c.Call = c.Call.ReturnError(err)
+	return c
+}
+
 // Drive mocks base method.
 func (m *MockUrbanResident) Drive(car cars.HyundaiSolaris) {
 	m.ctrl.T.Helper()
@@ -923,6 +1049,12 @@ func (c *MockUrbanResidentDriveCall) DoAndReturn(f func(cars.HyundaiSolaris)) *M
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockUrbanResidentDriveCall) Panic(v any) *MockUrbanResidentDriveCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Eat mocks base method.
 func (m *MockUrbanResident) Eat(foods ...package_mode.Food) {
 	m.ctrl.T.Helper()
@@ -963,6 +1095,12 @@ func (c *MockUrbanResidentEatCall) DoAndReturn(f func(...package_mode.Food)) *Mo
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockUrbanResidentEatCall) Panic(v any) *MockUrbanResidentEatCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // LivesInACity mocks base method.
 func (m *MockUrbanResident) LivesInACity() {
 	m.ctrl.T.Helper()
@@ -999,6 +1137,12 @@ func (c *MockUrbanResidentLivesInACityCall) DoAndReturn(f func()) *MockUrbanResi
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockUrbanResidentLivesInACityCall) Panic(v any) *MockUrbanResidentLivesInACityCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Sleep mocks base method.
 func (m *MockUrbanResident) Sleep(duration time.Duration) {
 	m.ctrl.T.Helper()
@@ -1035,6 +1179,12 @@ func (c *MockUrbanResidentSleepCall) DoAndReturn(f func(time.Duration)) *MockUrb
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockUrbanResidentSleepCall) Panic(v any) *MockUrbanResidentSleepCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Wroom mocks base method.
 func (m *MockUrbanResident) Wroom() error {
 	m.ctrl.T.Helper()
@@ -1073,6 +1223,18 @@ func (c *MockUrbanResidentWroomCall) DoAndReturn(f func() error) *MockUrbanResid
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockUrbanResidentWroomCall) Panic(v any) *MockUrbanResidentWroomCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
+// ReturnError rewrite *gomock.Call.ReturnError
+func (c *MockUrbanResidentWroomCall) ReturnError(err error) *MockUrbanResidentWroomCall {
+	c.Call = c.Call.ReturnError(err)
+	return c
+}
+
 // MockFarmer is a mock of Farmer interface.
 type MockFarmer struct {
 	ctrl     *gomock.Controller
@@ -1133,6 +1295,12 @@ func (c *MockFarmerBreatheCall) DoAndReturn(f func()) *MockFarmerBreatheCall {
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockFarmerBreatheCall) Panic(v any) *MockFarmerBreatheCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Do mocks base method.
 func (m *MockFarmer) Do(work *package_mode.Work) error {
 	m.ctrl.T.Helper()
@@ -1171,6 +1339,18 @@ func (c *MockFarmerDoCall) DoAndReturn(f func(*package_mode.Work) error) *MockFa
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockFarmerDoCall) Panic(v any) *MockFarmerDoCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
+// ReturnError rewrite *gomock.Call.ReturnError
+func (c *MockFarmerDoCall) ReturnError(err error) *MockFarmerDoCall {
+	c.Call = c.Call.ReturnError(err)
+	return c
+}
+
 // Drive mocks base method.
 func (m *MockFarmer) Drive(car cars.FordF150) {
 	m.ctrl.T.Helper()
@@ -1207,6 +1387,12 @@ func (c *MockFarmerDriveCall) DoAndReturn(f func(cars.FordF150)) *MockFarmerDriv
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockFarmerDriveCall) Panic(v any) *MockFarmerDriveCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Eat mocks base method.
 func (m *MockFarmer) Eat(foods ...package_mode.Food) {
 	m.ctrl.T.Helper()
@@ -1247,6 +1433,12 @@ func (c *MockFarmerEatCall) DoAndReturn(f func(...package_mode.Food)) *MockFarme
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockFarmerEatCall) Panic(v any) *MockFarmerEatCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // LivesInAVillage mocks base method.
 func (m *MockFarmer) LivesInAVillage() {
 	m.ctrl.T.Helper()
@@ -1283,6 +1475,12 @@ func (c *MockFarmerLivesInAVillageCall) DoAndReturn(f func()) *MockFarmerLivesIn
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockFarmerLivesInAVillageCall) Panic(v any) *MockFarmerLivesInAVillageCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Sleep mocks base method.
 func (m *MockFarmer) Sleep(duration time.Duration) {
 	m.ctrl.T.Helper()
@@ -1319,6 +1517,12 @@ func (c *MockFarmerSleepCall) DoAndReturn(f func(time.Duration)) *MockFarmerSlee
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockFarmerSleepCall) Panic(v any) *MockFarmerSleepCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Wroom mocks base method.
 func (m *MockFarmer) Wroom() error {
 	m.ctrl.T.Helper()
@@ -1357,6 +1561,18 @@ func (c *MockFarmerWroomCall) DoAndReturn(f func() error) *MockFarmerWroomCall {
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockFarmerWroomCall) Panic(v any) *MockFarmerWroomCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
+// ReturnError rewrite *gomock.Call.ReturnError
+func (c *MockFarmerWroomCall) ReturnError(err error) *MockFarmerWroomCall {
+	c.Call = c.Call.ReturnError(err)
+	return c
+}
+
 // MockEarth is a mock of Earth interface.
 type MockEarth struct {
 	ctrl     *gomock.Controller
@@ -1419,6 +1635,12 @@ func (c *MockEarthAddHumansCall) DoAndReturn(f func(package_mode.HumansCount) []
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockEarthAddHumansCall) Panic(v any) *MockEarthAddHumansCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // HumanPopulation mocks base method.
 func (m *MockEarth) HumanPopulation() package_mode.HumansCount {
 	m.ctrl.T.Helper()
@@ -1456,3 +1678,9 @@ func (c *MockEarthHumanPopulationCall) DoAndReturn(f func() package_mode.HumansC
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// Panic rewrite *gomock.Call.Panic
+func (c *MockEarthHumanPopulationCall) Panic(v any) *MockEarthHumanPopulationCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
@@ -0,0 +1,7 @@
+//go:build !integration && !plan9
+
+package source_build_tags
+
+type Backend interface {
+	Get(key string) ([]byte, error)
+}
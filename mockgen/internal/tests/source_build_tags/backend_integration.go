@@ -0,0 +1,7 @@
+//go:build integration
+
+package source_build_tags
+
+type Backend interface {
+	Query(q string) ([]string, error)
+}
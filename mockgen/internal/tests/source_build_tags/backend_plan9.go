@@ -0,0 +1,5 @@
+package source_build_tags
+
+type Backend interface {
+	Open(name string) error
+}
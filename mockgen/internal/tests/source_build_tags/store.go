@@ -0,0 +1,12 @@
+package source_build_tags
+
+// Source mode selects the files of the package like go build does: Backend is
+// declared in backend.go by default, in backend_integration.go with the
+// integration tag, and backend_plan9.go is only built on plan9.
+//go:generate mockgen -destination=mock1/store_mock.go -source=. Store
+//go:generate mockgen -destination=mock2/store_mock.go -source=. -build_flags=-tags=integration Store
+
+type Store interface {
+	Backend
+	Close() error
+}
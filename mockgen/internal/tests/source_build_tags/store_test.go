@@ -0,0 +1,10 @@
+//go:build !integration
+
+package source_build_tags_test
+
+import (
+	"go.uber.org/mock/mockgen/internal/tests/source_build_tags"
+	mock_source_build_tags "go.uber.org/mock/mockgen/internal/tests/source_build_tags/mock1"
+)
+
+var _ source_build_tags.Store = (*mock_source_build_tags.MockStore)(nil)
@@ -0,0 +1,12 @@
+package source_package
+
+//go:generate mockgen -package source_package -destination mock.go -source . Store
+
+import "io"
+
+// Store embeds Getter, which is declared in getter.go.
+type Store interface {
+	Getter
+	io.Closer
+	Put(key string, value []byte) error
+}
@@ -0,0 +1,23 @@
+package source_package
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestMockStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := NewMockStore(ctrl)
+	store.EXPECT().Get(gomock.Any(), "key").Return([]byte("value"), nil)
+	store.EXPECT().Close().Return(nil)
+
+	var s Store = store
+	if _, err := s.Get(context.Background(), "key"); err != nil {
+		t.Fatalf("Get() returned unexpected error: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() returned unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,14 @@
+package template
+
+import "context"
+
+//go:generate mockgen -package template -source=input.go -destination=mock.go -template=mock.tmpl
+
+type Store interface {
+	Get(ctx context.Context, id string) (string, error)
+	Put(ctx context.Context, id, value string) error
+}
+
+type Cache[K comparable, V any] interface {
+	Get(key K) (V, bool)
+}
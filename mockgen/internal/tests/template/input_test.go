@@ -0,0 +1,20 @@
+package template
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTemplateConstructors(t *testing.T) {
+	store := NewMockStoreT(t)
+	store.EXPECT().Get(context.Background(), "id").Return("value", nil)
+	if got, err := store.Get(context.Background(), "id"); got != "value" || err != nil {
+		t.Fatalf("Get() = %q, %v, want value, nil", got, err)
+	}
+
+	cache := NewMockCacheT[string, int](t)
+	cache.EXPECT().Get("key").Return(1, true)
+	if got, ok := cache.Get("key"); got != 1 || !ok {
+		t.Fatalf("Get() = %d, %t, want 1, true", got, ok)
+	}
+}
@@ -78,6 +78,12 @@ func (c *MockSourceErrorCall) DoAndReturn(f func() string) *MockSourceErrorCall
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockSourceErrorCall) Panic(v any) *MockSourceErrorCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Method mocks base method.
 func (m *MockSource) Method() faux.Return {
 	m.ctrl.T.Helper()
@@ -115,3 +121,9 @@ func (c *MockSourceMethodCall) DoAndReturn(f func() faux.Return) *MockSourceMeth
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// Panic rewrite *gomock.Call.Panic
+func (c *MockSourceMethodCall) Panic(v any) *MockSourceMethodCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
@@ -80,6 +80,12 @@ func (c *MockExternalConstraintEightCall[I, F]) DoAndReturn(f func(F) other.Two[
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockExternalConstraintEightCall[I, F]) Panic(v any) *MockExternalConstraintEightCall[I, F] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Five mocks base method.
 func (m *MockExternalConstraint[I, F]) Five(arg0 I) typed.Baz[F] {
 	m.ctrl.T.Helper()
@@ -118,6 +124,12 @@ func (c *MockExternalConstraintFiveCall[I, F]) DoAndReturn(f func(I) typed.Baz[F
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockExternalConstraintFiveCall[I, F]) Panic(v any) *MockExternalConstraintFiveCall[I, F] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Four mocks base method.
 func (m *MockExternalConstraint[I, F]) Four(arg0 I) typed.Foo[I, F] {
 	m.ctrl.T.Helper()
@@ -156,6 +168,12 @@ func (c *MockExternalConstraintFourCall[I, F]) DoAndReturn(f func(I) typed.Foo[I
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockExternalConstraintFourCall[I, F]) Panic(v any) *MockExternalConstraintFourCall[I, F] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Nine mocks base method.
 func (m *MockExternalConstraint[I, F]) Nine(arg0 typed.Iface[I]) {
 	m.ctrl.T.Helper()
@@ -192,6 +210,12 @@ func (c *MockExternalConstraintNineCall[I, F]) DoAndReturn(f func(typed.Iface[I]
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockExternalConstraintNineCall[I, F]) Panic(v any) *MockExternalConstraintNineCall[I, F] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // One mocks base method.
 func (m *MockExternalConstraint[I, F]) One(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -230,6 +254,12 @@ func (c *MockExternalConstraintOneCall[I, F]) DoAndReturn(f func(string) string)
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockExternalConstraintOneCall[I, F]) Panic(v any) *MockExternalConstraintOneCall[I, F] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Seven mocks base method.
 func (m *MockExternalConstraint[I, F]) Seven(arg0 I) other.One[I] {
 	m.ctrl.T.Helper()
@@ -268,6 +298,12 @@ func (c *MockExternalConstraintSevenCall[I, F]) DoAndReturn(f func(I) other.One[
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockExternalConstraintSevenCall[I, F]) Panic(v any) *MockExternalConstraintSevenCall[I, F] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Six mocks base method.
 func (m *MockExternalConstraint[I, F]) Six(arg0 I) *typed.Baz[F] {
 	m.ctrl.T.Helper()
@@ -306,6 +342,12 @@ func (c *MockExternalConstraintSixCall[I, F]) DoAndReturn(f func(I) *typed.Baz[F
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockExternalConstraintSixCall[I, F]) Panic(v any) *MockExternalConstraintSixCall[I, F] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Ten mocks base method.
 func (m *MockExternalConstraint[I, F]) Ten(arg0 *I) {
 	m.ctrl.T.Helper()
@@ -342,6 +384,12 @@ func (c *MockExternalConstraintTenCall[I, F]) DoAndReturn(f func(*I)) *MockExter
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockExternalConstraintTenCall[I, F]) Panic(v any) *MockExternalConstraintTenCall[I, F] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Three mocks base method.
 func (m *MockExternalConstraint[I, F]) Three(arg0 I) F {
 	m.ctrl.T.Helper()
@@ -380,6 +428,12 @@ func (c *MockExternalConstraintThreeCall[I, F]) DoAndReturn(f func(I) F) *MockEx
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockExternalConstraintThreeCall[I, F]) Panic(v any) *MockExternalConstraintThreeCall[I, F] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Two mocks base method.
 func (m *MockExternalConstraint[I, F]) Two(arg0 I) string {
 	m.ctrl.T.Helper()
@@ -417,3 +471,9 @@ func (c *MockExternalConstraintTwoCall[I, F]) DoAndReturn(f func(I) string) *Moc
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// Panic rewrite *gomock.Call.Panic
+func (c *MockExternalConstraintTwoCall[I, F]) Panic(v any) *MockExternalConstraintTwoCall[I, F] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
@@ -79,6 +79,12 @@ func (c *MockBarEightCall[T, R]) DoAndReturn(f func(T) other.Two[T, R]) *MockBar
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBarEightCall[T, R]) Panic(v any) *MockBarEightCall[T, R] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Eighteen mocks base method.
 func (m *MockBar[T, R]) Eighteen() (typed.Iface[*other.Five], error) {
 	m.ctrl.T.Helper()
@@ -118,6 +124,18 @@ func (c *MockBarEighteenCall[T, R]) DoAndReturn(f func() (typed.Iface[*other.Fiv
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBarEighteenCall[T, R]) Panic(v any) *MockBarEighteenCall[T, R] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
+// ReturnError rewrite *gomock.Call.ReturnError
+func (c *MockBarEighteenCall[T, R]) ReturnError(err error) *MockBarEighteenCall[T, R] {
+	c.Call = c.Call.ReturnError(err)
+	return c
+}
+
 // Eleven mocks base method.
 func (m *MockBar[T, R]) Eleven() (*other.One[T], error) {
 	m.ctrl.T.Helper()
@@ -157,6 +175,18 @@ func (c *MockBarElevenCall[T, R]) DoAndReturn(f func() (*other.One[T], error)) *
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBarElevenCall[T, R]) Panic(v any) *MockBarElevenCall[T, R] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
+// ReturnError rewrite *gomock.Call.ReturnError
+func (c *MockBarElevenCall[T, R]) ReturnError(err error) *MockBarElevenCall[T, R] {
+	c.Call = c.Call.ReturnError(err)
+	return c
+}
+
 // Fifteen mocks base method.
 func (m *MockBar[T, R]) Fifteen() (typed.Iface[typed.StructType], error) {
 	m.ctrl.T.Helper()
@@ -196,6 +226,18 @@ func (c *MockBarFifteenCall[T, R]) DoAndReturn(f func() (typed.Iface[typed.Struc
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBarFifteenCall[T, R]) Panic(v any) *MockBarFifteenCall[T, R] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
+// ReturnError rewrite *gomock.Call.ReturnError
+func (c *MockBarFifteenCall[T, R]) ReturnError(err error) *MockBarFifteenCall[T, R] {
+	c.Call = c.Call.ReturnError(err)
+	return c
+}
+
 // Five mocks base method.
 func (m *MockBar[T, R]) Five(arg0 T) typed.Baz[T] {
 	m.ctrl.T.Helper()
@@ -234,6 +276,12 @@ func (c *MockBarFiveCall[T, R]) DoAndReturn(f func(T) typed.Baz[T]) *MockBarFive
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBarFiveCall[T, R]) Panic(v any) *MockBarFiveCall[T, R] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Four mocks base method.
 func (m *MockBar[T, R]) Four(arg0 T) typed.Foo[T, R] {
 	m.ctrl.T.Helper()
@@ -272,6 +320,12 @@ func (c *MockBarFourCall[T, R]) DoAndReturn(f func(T) typed.Foo[T, R]) *MockBarF
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBarFourCall[T, R]) Panic(v any) *MockBarFourCall[T, R] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Fourteen mocks base method.
 func (m *MockBar[T, R]) Fourteen() (*typed.Foo[typed.StructType, typed.StructType2], error) {
 	m.ctrl.T.Helper()
@@ -311,6 +365,18 @@ func (c *MockBarFourteenCall[T, R]) DoAndReturn(f func() (*typed.Foo[typed.Struc
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBarFourteenCall[T, R]) Panic(v any) *MockBarFourteenCall[T, R] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
+// ReturnError rewrite *gomock.Call.ReturnError
+func (c *MockBarFourteenCall[T, R]) ReturnError(err error) *MockBarFourteenCall[T, R] {
+	c.Call = c.Call.ReturnError(err)
+	return c
+}
+
 // Nine mocks base method.
 func (m *MockBar[T, R]) Nine(arg0 typed.Iface[T]) {
 	m.ctrl.T.Helper()
@@ -347,6 +413,12 @@ func (c *MockBarNineCall[T, R]) DoAndReturn(f func(typed.Iface[T])) *MockBarNine
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBarNineCall[T, R]) Panic(v any) *MockBarNineCall[T, R] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Nineteen mocks base method.
 func (m *MockBar[T, R]) Nineteen() typed.AliasType {
 	m.ctrl.T.Helper()
@@ -385,6 +457,12 @@ func (c *MockBarNineteenCall[T, R]) DoAndReturn(f func() typed.AliasType) *MockB
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBarNineteenCall[T, R]) Panic(v any) *MockBarNineteenCall[T, R] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // One mocks base method.
 func (m *MockBar[T, R]) One(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -423,6 +501,12 @@ func (c *MockBarOneCall[T, R]) DoAndReturn(f func(string) string) *MockBarOneCal
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBarOneCall[T, R]) Panic(v any) *MockBarOneCall[T, R] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Seven mocks base method.
 func (m *MockBar[T, R]) Seven(arg0 T) other.One[T] {
 	m.ctrl.T.Helper()
@@ -461,6 +545,12 @@ func (c *MockBarSevenCall[T, R]) DoAndReturn(f func(T) other.One[T]) *MockBarSev
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBarSevenCall[T, R]) Panic(v any) *MockBarSevenCall[T, R] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Seventeen mocks base method.
 func (m *MockBar[T, R]) Seventeen() (*typed.Foo[other.Three, other.Four], error) {
 	m.ctrl.T.Helper()
@@ -500,6 +590,18 @@ func (c *MockBarSeventeenCall[T, R]) DoAndReturn(f func() (*typed.Foo[other.Thre
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBarSeventeenCall[T, R]) Panic(v any) *MockBarSeventeenCall[T, R] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
+// ReturnError rewrite *gomock.Call.ReturnError
+func (c *MockBarSeventeenCall[T, R]) ReturnError(err error) *MockBarSeventeenCall[T, R] {
+	c.Call = c.Call.ReturnError(err)
+	return c
+}
+
 // Six mocks base method.
 func (m *MockBar[T, R]) Six(arg0 T) *typed.Baz[T] {
 	m.ctrl.T.Helper()
@@ -538,6 +640,12 @@ func (c *MockBarSixCall[T, R]) DoAndReturn(f func(T) *typed.Baz[T]) *MockBarSixC
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBarSixCall[T, R]) Panic(v any) *MockBarSixCall[T, R] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Sixteen mocks base method.
 func (m *MockBar[T, R]) Sixteen() (typed.Baz[other.Three], error) {
 	m.ctrl.T.Helper()
@@ -577,6 +685,18 @@ func (c *MockBarSixteenCall[T, R]) DoAndReturn(f func() (typed.Baz[other.Three],
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBarSixteenCall[T, R]) Panic(v any) *MockBarSixteenCall[T, R] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
+// ReturnError rewrite *gomock.Call.ReturnError
+func (c *MockBarSixteenCall[T, R]) ReturnError(err error) *MockBarSixteenCall[T, R] {
+	c.Call = c.Call.ReturnError(err)
+	return c
+}
+
 // Ten mocks base method.
 func (m *MockBar[T, R]) Ten(arg0 *T) {
 	m.ctrl.T.Helper()
@@ -613,6 +733,12 @@ func (c *MockBarTenCall[T, R]) DoAndReturn(f func(*T)) *MockBarTenCall[T, R] {
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBarTenCall[T, R]) Panic(v any) *MockBarTenCall[T, R] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Thirteen mocks base method.
 func (m *MockBar[T, R]) Thirteen() (typed.Baz[typed.StructType], error) {
 	m.ctrl.T.Helper()
@@ -652,6 +778,18 @@ func (c *MockBarThirteenCall[T, R]) DoAndReturn(f func() (typed.Baz[typed.Struct
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBarThirteenCall[T, R]) Panic(v any) *MockBarThirteenCall[T, R] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
+// ReturnError rewrite *gomock.Call.ReturnError
+func (c *MockBarThirteenCall[T, R]) ReturnError(err error) *MockBarThirteenCall[T, R] {
+	c.Call = c.Call.ReturnError(err)
+	return c
+}
+
 // Three mocks base method.
 func (m *MockBar[T, R]) Three(arg0 T) R {
 	m.ctrl.T.Helper()
@@ -690,6 +828,12 @@ func (c *MockBarThreeCall[T, R]) DoAndReturn(f func(T) R) *MockBarThreeCall[T, R
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBarThreeCall[T, R]) Panic(v any) *MockBarThreeCall[T, R] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
 // Twelve mocks base method.
 func (m *MockBar[T, R]) Twelve() (*other.Two[T, R], error) {
 	m.ctrl.T.Helper()
@@ -729,6 +873,18 @@ func (c *MockBarTwelveCall[T, R]) DoAndReturn(f func() (*other.Two[T, R], error)
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBarTwelveCall[T, R]) Panic(v any) *MockBarTwelveCall[T, R] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
+// ReturnError rewrite *gomock.Call.ReturnError
+func (c *MockBarTwelveCall[T, R]) ReturnError(err error) *MockBarTwelveCall[T, R] {
+	c.Call = c.Call.ReturnError(err)
+	return c
+}
+
 // Two mocks base method.
 func (m *MockBar[T, R]) Two(arg0 T) string {
 	m.ctrl.T.Helper()
@@ -766,3 +922,9 @@ func (c *MockBarTwoCall[T, R]) DoAndReturn(f func(T) string) *MockBarTwoCall[T,
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// Panic rewrite *gomock.Call.Panic
+func (c *MockBarTwoCall[T, R]) Panic(v any) *MockBarTwoCall[T, R] {
+	c.Call = c.Call.Panic(v)
+	return c
+}
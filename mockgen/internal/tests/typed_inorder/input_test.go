@@ -25,3 +25,31 @@ func TestInteract(t *testing.T) {
 		t.Fatalf("sad")
 	}
 }
+
+func TestInteract_FeedError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	errChocolate := fmt.Errorf("Dogs can't eat chocolate!")
+	mockAnimal := NewMockAnimal(ctrl)
+	mockAnimal.EXPECT().Feed("chocolate").ReturnError(errChocolate)
+	if _, err := Interact(mockAnimal, "chocolate"); err != errChocolate {
+		t.Fatalf("got error %v, want %v", err, errChocolate)
+	}
+}
+
+func TestInteract_Panic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockAnimal := NewMockAnimal(ctrl)
+	gomock.InOrder(
+		mockAnimal.EXPECT().Feed("burguir").Return(nil),
+		mockAnimal.EXPECT().GetSound().Panic("no sound"),
+	)
+	defer func() {
+		if r := recover(); r != "no sound" {
+			t.Errorf("got panic %v, want %q", r, "no sound")
+		}
+	}()
+	_, _ = Interact(mockAnimal, "burguir")
+	t.Error("expected Interact to panic")
+}
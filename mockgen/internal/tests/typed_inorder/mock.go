@@ -77,6 +77,18 @@ func (c *MockAnimalFeedCall) DoAndReturn(f func(string) error) *MockAnimalFeedCa
 	return c
 }
 
+// Panic rewrite *gomock.Call.Panic
+func (c *MockAnimalFeedCall) Panic(v any) *MockAnimalFeedCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
+
+// ReturnError rewrite *gomock.Call.ReturnError
+func (c *MockAnimalFeedCall) ReturnError(err error) *MockAnimalFeedCall {
+	c.Call = c.Call.ReturnError(err)
+	return c
+}
+
 // GetSound mocks base method.
 func (m *MockAnimal) GetSound() string {
 	m.ctrl.T.Helper()
@@ -114,3 +126,9 @@ func (c *MockAnimalGetSoundCall) DoAndReturn(f func() string) *MockAnimalGetSoun
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// Panic rewrite *gomock.Call.Panic
+func (c *MockAnimalGetSoundCall) Panic(v any) *MockAnimalGetSoundCall {
+	c.Call = c.Call.Panic(v)
+	return c
+}
@@ -0,0 +1,29 @@
+package unsupported
+
+//go:generate mockgen -package source -destination source/mock.go -source input.go -skip_unsupported
+//go:generate mockgen -package package_mode -destination package_mode/mock.go -skip_unsupported . Number,Sum,Scaler,Visitor,Store
+
+// Number is a constraint, which cannot be mocked.
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+// Sum has a type-set constraint.
+type Sum[T ~int | ~float64] interface {
+	Add(a, b T) T
+}
+
+// Scaler has a named constraint.
+type Scaler[T Number] interface {
+	Scale(v T, factor float64) T
+}
+
+// Visitor takes a non-empty unnamed interface, which cannot be modeled.
+type Visitor interface {
+	Visit(node interface{ Pos() int }) error
+	Done()
+}
+
+type Store interface {
+	Get(key string) (string, error)
+}
@@ -0,0 +1,17 @@
+package unsupported_test
+
+import (
+	"go.uber.org/mock/mockgen/internal/tests/unsupported"
+	"go.uber.org/mock/mockgen/internal/tests/unsupported/package_mode"
+	"go.uber.org/mock/mockgen/internal/tests/unsupported/source"
+)
+
+var (
+	_ unsupported.Sum[int]        = (*source.MockSum[int])(nil)
+	_ unsupported.Scaler[float64] = (*source.MockScaler[float64])(nil)
+	_ unsupported.Store           = (*source.MockStore)(nil)
+
+	_ unsupported.Sum[int]        = (*package_mode.MockSum[int])(nil)
+	_ unsupported.Scaler[float64] = (*package_mode.MockScaler[float64])(nil)
+	_ unsupported.Store           = (*package_mode.MockStore)(nil)
+)
@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: go.uber.org/mock/mockgen/internal/tests/unsupported (interfaces: Number,Sum,Scaler,Visitor,Store)
+//
+// Generated by this command:
+//
+//	mockgen -package package_mode -destination package_mode/mock.go -skip_unsupported . Number,Sum,Scaler,Visitor,Store
+//
+
+// Package package_mode is a generated GoMock package.
+package package_mode
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	unsupported "go.uber.org/mock/mockgen/internal/tests/unsupported"
+)
+
+// MockSum is a mock of Sum interface.
+type MockSum[T ~int | ~float64] struct {
+	ctrl     *gomock.Controller
+	recorder *MockSumMockRecorder[T]
+	isgomock struct{}
+}
+
+// MockSumMockRecorder is the mock recorder for MockSum.
+type MockSumMockRecorder[T ~int | ~float64] struct {
+	mock *MockSum[T]
+}
+
+// NewMockSum creates a new mock instance.
+func NewMockSum[T ~int | ~float64](ctrl *gomock.Controller) *MockSum[T] {
+	mock := &MockSum[T]{ctrl: ctrl}
+	mock.recorder = &MockSumMockRecorder[T]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSum[T]) EXPECT() *MockSumMockRecorder[T] {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockSum[T]) Add(a, b T) T {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", a, b)
+	ret0, _ := ret[0].(T)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockSumMockRecorder[T]) Add(a, b any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockSum[T])(nil).Add), a, b)
+}
+
+// MockScaler is a mock of Scaler interface.
+type MockScaler[T unsupported.Number] struct {
+	ctrl     *gomock.Controller
+	recorder *MockScalerMockRecorder[T]
+	isgomock struct{}
+}
+
+// MockScalerMockRecorder is the mock recorder for MockScaler.
+type MockScalerMockRecorder[T unsupported.Number] struct {
+	mock *MockScaler[T]
+}
+
+// NewMockScaler creates a new mock instance.
+func NewMockScaler[T unsupported.Number](ctrl *gomock.Controller) *MockScaler[T] {
+	mock := &MockScaler[T]{ctrl: ctrl}
+	mock.recorder = &MockScalerMockRecorder[T]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScaler[T]) EXPECT() *MockScalerMockRecorder[T] {
+	return m.recorder
+}
+
+// Scale mocks base method.
+func (m *MockScaler[T]) Scale(v T, factor float64) T {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scale", v, factor)
+	ret0, _ := ret[0].(T)
+	return ret0
+}
+
+// Scale indicates an expected call of Scale.
+func (mr *MockScalerMockRecorder[T]) Scale(v, factor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scale", reflect.TypeOf((*MockScaler[T])(nil).Scale), v, factor)
+}
+
+// MockStore is a mock of Store interface.
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore.
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance.
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockStore) Get(key string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockStoreMockRecorder) Get(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStore)(nil).Get), key)
+}
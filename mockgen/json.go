@@ -0,0 +1,33 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"go.uber.org/mock/mockgen/model"
+)
+
+func jsonMode(path string) (*model.Package, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var pkg model.Package
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, err
+	}
+	return &pkg, nil
+}
+
+// encodeModel encodes pkg in the -emit_model format.
+func encodeModel(pkg *model.Package, format string) ([]byte, error) {
+	if format != "json" {
+		return nil, fmt.Errorf("unknown model format %q, want json", format)
+	}
+	data, err := json.MarshalIndent(pkg, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
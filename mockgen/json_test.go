@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/mockgen/generate"
+)
+
+func TestJSONMode(t *testing.T) {
+	// Encode a package to a temporary JSON file.
+	pkg, err := generate.ParseSource(
+		"internal/tests/instantiation/input.go",
+		[]string{"Repo", "Cache", "Repo[go.uber.org/mock/mockgen/internal/tests/instantiation/models.User]"},
+		generate.LoadOptions{},
+	)
+	require.NoError(t, err)
+	data, err := encodeModel(pkg, "json")
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "model.json")
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+
+	// Ensure jsonMode loads a model generating the same mocks.
+	got, err := jsonMode(path)
+	require.NoError(t, err)
+	want, err := generate.Generate(pkg, generate.Options{})
+	require.NoError(t, err)
+	gotOutput, err := generate.Generate(got, generate.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(gotOutput))
+
+	// The model is stable across a round trip.
+	again, err := encodeModel(got, "json")
+	require.NoError(t, err)
+	assert.JSONEq(t, string(data), string(again))
+
+	_, err = encodeModel(pkg, "yaml")
+	assert.EqualError(t, err, `unknown model format "yaml", want json`)
+}
@@ -20,33 +20,21 @@ package main
 
 import (
 	"bytes"
-	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
-	"go/token"
 	"io"
 	"log"
 	"os"
-	"os/exec"
-	"path"
 	"path/filepath"
-	"runtime"
-	"sort"
-	"strconv"
 	"strings"
-	"unicode"
 
-	"golang.org/x/mod/modfile"
-	toolsimports "golang.org/x/tools/imports"
+	"github.com/pmezard/go-difflib/difflib"
 
+	"go.uber.org/mock/mockgen/generate"
 	"go.uber.org/mock/mockgen/model"
 )
 
-const (
-	gomockImportPath = "go.uber.org/mock/gomock"
-)
-
 var (
 	version = ""
 	commit  = "none"
@@ -55,7 +43,9 @@ var (
 
 var (
 	archive                = flag.String("archive", "", "(archive mode) Input Go archive file; enables archive mode.")
-	source                 = flag.String("source", "", "(source mode) Input Go source file; enables source mode.")
+	importcfg              = flag.String("importcfg", "", "(archive mode) Compiler importcfg file whose packagefile lines give the export data files of dependencies, e.g. packagefile io=/path/io.a.")
+	archiveDepSpecs        = stringListFlag("archive_dep", "(archive mode) Export data file of a dependency, as path=file. May be repeated; takes precedence over -importcfg.")
+	source                 = flag.String("source", "", "(source mode) Input Go source file, or directory or import path of a package whose non-test files are parsed together; enables source mode.")
 	destination            = flag.String("destination", "", "Output file; defaults to stdout.")
 	mockNames              = flag.String("mock_names", "", "Comma-separated interfaceName=mockName pairs of explicit mock names to use. Mock names default to 'Mock'+ interfaceName suffix.")
 	packageOut             = flag.String("package", "", "Package of the generated code; defaults to the package of the input with a 'mock_' prefix.")
@@ -64,14 +54,26 @@ var (
 	writePkgComment        = flag.Bool("write_package_comment", true, "Writes package documentation comment (godoc) if true.")
 	writeSourceComment     = flag.Bool("write_source_comment", true, "Writes original file (source mode) or interface names (package mode) comment if true.")
 	writeGenerateDirective = flag.Bool("write_generate_directive", false, "Add //go:generate directive to regenerate the mock")
+	writeDocComments       = flag.Bool("write_doc_comments", false, "Copy the doc comments of the mocked interfaces and methods to the generated code.")
 	copyrightFile          = flag.String("copyright_file", "", "Copyright file used to add copyright header")
+	templateFile           = flag.String("template", "", "Path to a text/template of the generated code, used instead of the built-in template. See the documentation of the generate package for its data and functions.")
 	buildConstraint        = flag.String("build_constraint", "", "If non-empty, added as //go:build <constraint>")
 	typed                  = flag.Bool("typed", false, "Generate Type-safe 'Return', 'Do', 'DoAndReturn' function")
+	argBuilders            = flag.Int("arg_builders", 0, "If positive, generate EXPECT builders with named arguments, e.g. EXPECT().FooWith().UserID(x).Call(), for the methods with at least this many parameters. Unset arguments match anything.")
+	style                  = flag.String("style", generate.StyleMock, "Style of the generated code: 'mock' for mocks checking expectations, or 'fake' for counterfeiter-style fakes with configurable return values.")
 	imports                = flag.String("imports", "", "(source mode) Comma-separated name=path pairs of explicit imports to use.")
 	auxFiles               = flag.String("aux_files", "", "(source mode) Comma-separated pkg=path pairs of auxiliary Go source files.")
 	modelGob               = flag.String("model_gob", "", "Skip package/source loading entirely and use the gob encoded model.Package at the given path")
+	modelJSON              = flag.String("model_json", "", "Skip package/source loading entirely and use the JSON encoded model.Package at the given path")
+	emitModel              = flag.String("emit_model", "", "If set to 'json', write the JSON encoded model.Package instead of the mocks.")
 	excludeInterfaces      = flag.String("exclude_interfaces", "", "Comma-separated names of interfaces to be excluded")
 	debugParser            = flag.Bool("debug_parser", false, "Print out parser results only.")
+	buildFlags             = flag.String("build_flags", "", "(package and source mode) Additional flags for go build. In source mode, -tags selects the files to parse.")
+	hermetic               = flag.Bool("hermetic", false, "(package mode) Type-check the package and its dependencies from source instead of building them with the go command. Imports are resolved from go.mod or go.work, the vendor directory, GOROOT and the module cache.")
+	extractInterfaces      = flag.Bool("extract_interfaces", false, "(archive and package mode) Accept concrete types among the symbols. An interface is extracted from the exported method set of a pointer to the type, and both the interface and its mock are generated.")
+	skipUnsupported        = flag.Bool("skip_unsupported", false, "Skip the interfaces that cannot be mocked, such as those with methods taking non-empty unnamed interfaces, instead of failing. Skipped interfaces are logged.")
+	compose                = flag.String("compose", "", "Semicolon-separated Name=iface1,iface2,... specs. For each spec, a single mock implementing all of the listed interfaces is generated. Interfaces are given by their qualified name, e.g. io.Reader or example.com/x.Store.")
+	check                  = flag.Bool("check", false, "Do not write anything; exit with a non-zero status and print a unified diff if -destination is not up to date.")
 	showVersion            = flag.Bool("version", false, "Print version.")
 )
 
@@ -90,26 +92,66 @@ func main() {
 	var err error
 	var packageName string
 
+	var compositions []generate.Composition
+	if *compose != "" {
+		compositions, err = parseCompositions(*compose)
+		if err != nil {
+			log.Fatalf("Parse -compose failed: %v", err)
+		}
+	}
+
+	loadOpts, err := loadOptions()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// The export data of the packages of composed interfaces and the sources
+	// the doc comments are read from are not part of the cache key, so such
+	// mocks are never cached.
+	cache := newOutputCache(*cacheDir)
+	if len(compositions) > 0 || *emitModel != "" || *writeDocComments {
+		cache = nil
+	}
+
 	// Switch between modes
 	switch {
 	case *modelGob != "": // gob mode
 		pkg, err = gobMode(*modelGob)
+	case *modelJSON != "": // json mode
+		pkg, err = jsonMode(*modelJSON)
 	case *source != "": // source mode
-		pkg, err = sourceMode(*source)
+		if flag.NArg() > 1 {
+			log.Fatal("Loading input failed: -source mode accepts at most one argument")
+		}
+		var interfaces []string
+		if flag.NArg() == 1 {
+			interfaces = generate.SplitSymbols(flag.Arg(0))
+		}
+		pkg, err = generate.ParseSource(*source, interfaces, loadOpts)
 	case *archive != "": // archive mode
 		checkArgsArchive()
 		packageName = flag.Arg(0)
 		var interfaces []string
 		if flag.NArg() > 1 {
-			interfaces = strings.Split(flag.Arg(1), ",")
+			interfaces = generate.SplitSymbols(flag.Arg(1))
+		}
+		if output, ok := cache.lookup(*archive, loadOpts.ArchiveDeps); ok {
+			writeOutput(output)
+			return
+		}
+		// If no interfaces specified, LoadArchive will discover all interfaces
+		pkg, err = generate.LoadArchive(*archive, packageName, interfaces, loadOpts)
+
+	case flag.NArg() == 0 && len(compositions) > 0: // composite mocks only
+		if *packageOut == "" {
+			log.Fatal("-compose without an input package requires -package")
 		}
-		// If no interfaces specified, parseExportFile will discover all interfaces
-		pkg, err = parseExportFile(packageName, interfaces, *archive)
+		pkg = &model.Package{}
 
 	default: // package mode
 		checkArgsPackage()
 		packageName = flag.Arg(0)
-		interfaces := strings.Split(flag.Arg(1), ",")
+		interfaces := generate.SplitSymbols(flag.Arg(1))
 
 		if packageName == "." {
 			dir, err := os.Getwd()
@@ -122,62 +164,81 @@ func main() {
 			}
 
 		}
-		parser := packageModeParser{}
-		pkg, err = parser.parsePackage(packageName, interfaces)
+		if *hermetic {
+			// There is no export data to key the cache with.
+			pkg, err = generate.LoadPackage(packageName, interfaces, loadOpts)
+			break
+		}
+		var exportFile string
+		exportFile, err = generate.ExportFile(packageName, loadOpts)
+		if err == nil {
+			if output, ok := cache.lookup(exportFile, loadOpts.ArchiveDeps); ok {
+				writeOutput(output)
+				return
+			}
+			pkg, err = generate.LoadArchive(exportFile, packageName, interfaces, loadOpts)
+		}
 	}
 
 	if err != nil {
 		log.Fatalf("Loading input failed: %v", err)
 	}
 
+	if len(compositions) > 0 {
+		intfs, err := generate.Compose(compositions, loadOpts)
+		if err != nil {
+			log.Fatalf("Composing interfaces failed: %v", err)
+		}
+		pkg.Interfaces = append(pkg.Interfaces, intfs...)
+	}
+
 	if *debugParser {
 		pkg.Print(os.Stdout)
 		return
 	}
 
-	outputPackageName := *packageOut
-	if outputPackageName == "" {
-		// pkg.Name in package mode is the base name of the import path,
-		// which might have characters that are illegal to have in package names.
-		outputPackageName = "mock_" + sanitize(pkg.Name)
-	}
-
-	// outputPackagePath represents the fully qualified name of the package of
-	// the generated code. Its purposes are to prevent the module from importing
-	// itself and to prevent qualifying type names that come from its own
-	// package (i.e. if there is a type called X then we want to print "X" not
-	// "package.X" since "package" is this package). This can happen if the mock
-	// is output into an already existing package.
-	outputPackagePath := *selfPackage
-	if outputPackagePath == "" && *destination != "" {
-		dstPath, err := filepath.Abs(filepath.Dir(*destination))
-		if err == nil {
-			pkgPath, err := parsePackageImport(dstPath)
-			if err == nil {
-				outputPackagePath = pkgPath
-			} else {
-				log.Println("Unable to infer -self_package from destination file path:", err)
-			}
-		} else {
-			log.Println("Unable to determine destination file path:", err)
+	if *emitModel != "" {
+		output, err := encodeModel(pkg, *emitModel)
+		if err != nil {
+			log.Fatalf("Failed encoding model: %v", err)
 		}
+		writeOutput(output)
+		return
 	}
 
-	g := &generator{
-		buildConstraint: *buildConstraint,
+	opts := generate.Options{
+		PackageName:            *packageOut,
+		SelfPackage:            *selfPackage,
+		Destination:            *destination,
+		Imports:                loadOpts.Imports,
+		Style:                  *style,
+		Typed:                  *typed,
+		ArgBuilders:            *argBuilders,
+		BuildConstraint:        *buildConstraint,
+		Command:                append([]string{os.Args[0]}, commandArgs()...),
+		WriteCommandComment:    *writeCmdComment,
+		WritePackageComment:    *writePkgComment,
+		WriteGenerateDirective: *writeGenerateDirective,
+		DocComments:            *writeDocComments,
 	}
-	if *source != "" {
-		g.filename = *source
-	} else if *archive != "" {
-		g.filename = *archive
-	} else {
-		g.srcPackage = packageName
-		g.srcInterfaces = flag.Arg(1)
+	if *writeSourceComment {
+		switch {
+		case *modelGob != "":
+			opts.Source = *modelGob
+		case *modelJSON != "":
+			opts.Source = *modelJSON
+		case *source != "":
+			opts.Source = *source
+		case *archive != "":
+			opts.Source = *archive
+		case packageName != "":
+			opts.Source = fmt.Sprintf("%v (interfaces: %v)", packageName, flag.Arg(1))
+		case *compose != "":
+			opts.Source = fmt.Sprintf("composed interfaces (%v)", *compose)
+		}
 	}
-	g.destination = *destination
-
 	if *mockNames != "" {
-		g.mockNames = parseMockNames(*mockNames)
+		opts.MockNames = parseMockNames(*mockNames)
 	}
 	if *copyrightFile != "" {
 		header, err := os.ReadFile(*copyrightFile)
@@ -185,12 +246,77 @@ func main() {
 			log.Fatalf("Failed reading copyright file: %v", err)
 		}
 
-		g.copyrightHeader = string(header)
+		opts.CopyrightHeader = string(header)
+	}
+	if *templateFile != "" {
+		tmpl, err := os.ReadFile(*templateFile)
+		if err != nil {
+			log.Fatalf("Failed reading template file: %v", err)
+		}
+
+		opts.Template = string(tmpl)
 	}
-	if err := g.Generate(pkg, outputPackageName, outputPackagePath); err != nil {
+	output, err := generate.Generate(pkg, opts)
+	if err != nil {
 		log.Fatalf("Failed generating mock: %v", err)
 	}
-	output := g.Output()
+	cache.store(output)
+	writeOutput(output)
+}
+
+// loadOptions returns the options for loading the input from the flags.
+func loadOptions() (generate.LoadOptions, error) {
+	opts := generate.LoadOptions{
+		ExtractInterfaces: *extractInterfaces,
+		Hermetic:          *hermetic,
+		SkipUnsupported:   *skipUnsupported,
+	}
+	if *buildFlags != "" {
+		opts.BuildFlags = strings.Split(*buildFlags, " ")
+	}
+	if *imports != "" {
+		opts.Imports = make(map[string]string)
+		for _, kv := range strings.Split(*imports, ",") {
+			eq := strings.Index(kv, "=")
+			if eq < 0 {
+				return opts, fmt.Errorf("bad imports spec: %v", kv)
+			}
+			k, v := kv[:eq], kv[eq+1:]
+			if k == "." {
+				opts.DotImports = append(opts.DotImports, v)
+			} else {
+				opts.Imports[k] = v
+			}
+		}
+	}
+	if *auxFiles = strings.TrimSpace(*auxFiles); *auxFiles != "" {
+		for _, kv := range strings.Split(*auxFiles, ",") {
+			parts := strings.SplitN(kv, "=", 2)
+			if len(parts) != 2 {
+				return opts, fmt.Errorf("bad aux file spec: %v", kv)
+			}
+			opts.AuxFiles = append(opts.AuxFiles, generate.AuxFile{Package: parts[0], Path: parts[1]})
+		}
+	}
+	deps, err := archiveDeps(*importcfg, *archiveDepSpecs)
+	if err != nil {
+		return opts, fmt.Errorf("bad archive deps: %w", err)
+	}
+	opts.ArchiveDeps = deps
+	for name := range parseExcludeInterfaces(*excludeInterfaces) {
+		opts.ExcludeInterfaces = append(opts.ExcludeInterfaces, name)
+	}
+	return opts, nil
+}
+
+// writeOutput writes the generated code to -destination, or to stdout if
+// no destination is set. An up-to-date destination file is left untouched.
+// In -check mode the destination is only compared with the output.
+func writeOutput(output []byte) {
+	if *check {
+		checkOutput(output)
+		return
+	}
 	dst := os.Stdout
 	if len(*destination) > 0 {
 		if err := os.MkdirAll(filepath.Dir(*destination), os.ModePerm); err != nil {
@@ -215,6 +341,48 @@ func main() {
 	}
 }
 
+// checkOutput compares output with the existing destination file. If they
+// differ, it prints a unified diff and exits with a non-zero status.
+func checkOutput(output []byte) {
+	if *destination == "" {
+		log.Fatal("-check requires -destination")
+	}
+	existing, err := os.ReadFile(*destination)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Fatalf("Failed reading pre-exiting destination file: %v", err)
+	}
+	if bytes.Equal(existing, output) {
+		return
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(existing)),
+		B:        difflib.SplitLines(string(output)),
+		FromFile: *destination,
+		ToFile:   *destination + " (generated)",
+		Context:  3,
+	})
+	if err != nil {
+		log.Fatalf("Failed computing diff: %v", err)
+	}
+	fmt.Print(diff)
+	log.Fatalf("%s is out of date", *destination)
+}
+
+// commandArgs returns the command line arguments that affect the generated
+// code. Flags that only control how the output is handled, such as -check,
+// are dropped so that checking a mock yields the same output as writing it.
+func commandArgs() []string {
+	args := make([]string, 0, len(os.Args)-1)
+	for _, arg := range os.Args[1:] {
+		name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if strings.HasPrefix(arg, "-") && name == "check" {
+			continue
+		}
+		args = append(args, arg)
+	}
+	return args
+}
+
 func parseMockNames(names string) map[string]string {
 	mocksMap := make(map[string]string)
 	for _, kv := range strings.Split(names, ",") {
@@ -266,11 +434,13 @@ func usage() {
 
 const usageText = `mockgen has three modes of operation: archive, source and package.
 
-Source mode generates mock interfaces from a source file.
+Source mode generates mock interfaces from a source file, or from all the
+non-test files of a package given by its directory or import path.
 It is enabled by using the -source flag. Other flags that
 may be useful in this mode are -imports, -aux_files and -exclude_interfaces.
 Example:
 	mockgen -source=foo.go [other options]
+	mockgen -source=./foo [other options]
 
 Package mode works by specifying the package and interface names.
 It is enabled by passing two non-flag arguments: an import path, and a
@@ -288,623 +458,37 @@ Example:
 	mockgen -archive=pkg.a database/sql/driver Conn,Driver
 	mockgen -archive=pkg.a database/sql/driver
 
-`
-
-type generator struct {
-	buf                       bytes.Buffer
-	indent                    string
-	mockNames                 map[string]string // may be empty
-	filename                  string            // may be empty
-	destination               string            // may be empty
-	srcPackage, srcInterfaces string            // may be empty
-	copyrightHeader           string
-	buildConstraint           string // may be empty
-
-	packageMap map[string]string // map from import path to package name
-}
-
-func (g *generator) p(format string, args ...any) {
-	_, _ = fmt.Fprintf(&g.buf, g.indent+format+"\n", args...)
-}
-
-func (g *generator) in() {
-	g.indent += "\t"
-}
-
-func (g *generator) out() {
-	if len(g.indent) > 0 {
-		g.indent = g.indent[0 : len(g.indent)-1]
-	}
-}
-
-// sanitize cleans up a string to make a suitable package name.
-func sanitize(s string) string {
-	t := ""
-	for _, r := range s {
-		if t == "" {
-			if unicode.IsLetter(r) || r == '_' {
-				t += string(r)
-				continue
-			}
-		} else {
-			if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
-				t += string(r)
-				continue
-			}
-		}
-		t += "_"
-	}
-	if t == "_" {
-		t = "x"
-	}
-	return t
-}
-
-func (g *generator) Generate(pkg *model.Package, outputPkgName string, outputPackagePath string) error {
-	if outputPkgName != pkg.Name && *selfPackage == "" {
-		// reset outputPackagePath if it's not passed in through -self_package
-		outputPackagePath = ""
-	}
-
-	if g.copyrightHeader != "" {
-		lines := strings.Split(g.copyrightHeader, "\n")
-		for _, line := range lines {
-			g.p("// %s", line)
-		}
-		g.p("")
-	}
+In every mode, -compose additionally generates a single mock implementing
+several interfaces, which may live in different packages. Without any other
+input, only the composite mocks are generated and -package is required.
+Example:
+	mockgen -package=mock_store -compose='ReadCloserStore=io.Reader,io.Closer,example.com/x.Store'
 
-	if g.buildConstraint != "" {
-		g.p("//go:build %s", g.buildConstraint)
-		// https://pkg.go.dev/cmd/go#hdr-Build_constraints:~:text=a%20build%20constraint%20should%20be%20followed%20by%20a%20blank%20line
-		g.p("")
-	}
+`
 
-	g.p("// Code generated by MockGen. DO NOT EDIT.")
-	if *writeSourceComment {
-		if g.filename != "" {
-			g.p("// Source: %v", g.filename)
-		} else {
-			g.p("// Source: %v (interfaces: %v)", g.srcPackage, g.srcInterfaces)
-		}
-	}
-	if *writeCmdComment {
-		g.p("//")
-		g.p("// Generated by this command:")
-		g.p("//")
-		// only log the name of the executable, not the full path
-		name := filepath.Base(os.Args[0])
-		if runtime.GOOS == "windows" {
-			name = strings.TrimSuffix(name, ".exe")
-		}
-		g.p("//\t%v", strings.Join(append([]string{name}, os.Args[1:]...), " "))
-		g.p("//")
+// packageNameOfDir get package import path via dir
+func packageNameOfDir(srcDir string) (string, error) {
+	files, err := os.ReadDir(srcDir)
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	// Get all required imports, and generate unique names for them all.
-	im := pkg.Imports()
-	im[gomockImportPath] = true
-
-	// Only import reflect if it's used. We only use reflect in mocked methods
-	// so only import if any of the mocked interfaces have methods.
-	for _, intf := range pkg.Interfaces {
-		if len(intf.Methods) > 0 {
-			im["reflect"] = true
+	var goFilePath string
+	for _, file := range files {
+		if !file.IsDir() && strings.HasSuffix(file.Name(), ".go") {
+			goFilePath = file.Name()
 			break
 		}
 	}
-
-	// Sort keys to make import alias generation predictable
-	sortedPaths := make([]string, len(im))
-	x := 0
-	for pth := range im {
-		sortedPaths[x] = pth
-		x++
+	if goFilePath == "" {
+		return "", fmt.Errorf("go source file not found %s", srcDir)
 	}
-	sort.Strings(sortedPaths)
-
-	packagesName := createPackageMap(sortedPaths)
 
-	definedImports := make(map[string]string, len(im))
-	if *imports != "" {
-		for _, kv := range strings.Split(*imports, ",") {
-			eq := strings.Index(kv, "=")
-			if k, v := kv[:eq], kv[eq+1:]; k != "." {
-				definedImports[v] = k
-			}
-		}
-	}
-
-	g.packageMap = make(map[string]string, len(im))
-	localNames := make(map[string]bool, len(im))
-	for _, pth := range sortedPaths {
-		base, ok := packagesName[pth]
-		if !ok {
-			base = sanitize(path.Base(pth))
-		}
-
-		// Local names for an imported package can usually be the basename of the import path.
-		// A couple of situations don't permit that, such as duplicate local names
-		// (e.g. importing "html/template" and "text/template"), or where the basename is
-		// a keyword (e.g. "foo/case") or when defining a name for that by using the -imports flag.
-		// try base0, base1, ...
-		pkgName := base
-
-		if _, ok := definedImports[pth]; ok {
-			pkgName = definedImports[pth]
-		}
-
-		i := 0
-		for localNames[pkgName] || token.Lookup(pkgName).IsKeyword() || pkgName == "any" {
-			pkgName = base + strconv.Itoa(i)
-			i++
-		}
-
-		// Avoid importing package if source pkg == output pkg
-		if pth == pkg.PkgPath && outputPackagePath == pkg.PkgPath {
-			continue
-		}
-
-		g.packageMap[pth] = pkgName
-		localNames[pkgName] = true
-	}
-
-	// Ensure there is an empty line between “generated by” block and
-	// package documentation comments to follow the recommendations:
-	// https://go.dev/wiki/CodeReviewComments#package-comments
-	// That is, “generated by” should not be a package comment.
-	g.p("")
-
-	if *writePkgComment {
-		g.p("// Package %v is a generated GoMock package.", outputPkgName)
-	}
-	g.p("package %v", outputPkgName)
-	g.p("")
-	g.p("import (")
-	g.in()
-	for pkgPath, pkgName := range g.packageMap {
-		if pkgPath == outputPackagePath {
-			continue
-		}
-		g.p("%v %q", pkgName, pkgPath)
-	}
-	for _, pkgPath := range pkg.DotImports {
-		g.p(". %q", pkgPath)
-	}
-	g.out()
-	g.p(")")
-
-	if *writeGenerateDirective {
-		g.p("//go:generate %v", strings.Join(os.Args, " "))
-	}
-
-	for _, intf := range pkg.Interfaces {
-		if err := g.GenerateMockInterface(intf, outputPackagePath); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// The name of the mock type to use for the given interface identifier.
-func (g *generator) mockName(typeName string) string {
-	if mockName, ok := g.mockNames[typeName]; ok {
-		return mockName
-	}
-
-	return "Mock" + typeName
-}
-
-// formattedTypeParams returns a long and short form of type param info used for
-// printing. If analyzing a interface with type param [I any, O any] the result
-// will be:
-// "[I any, O any]", "[I, O]"
-func (g *generator) formattedTypeParams(it *model.Interface, pkgOverride string) (string, string) {
-	if len(it.TypeParams) == 0 {
-		return "", ""
-	}
-	var long, short strings.Builder
-	long.WriteString("[")
-	short.WriteString("[")
-	for i, v := range it.TypeParams {
-		if i != 0 {
-			long.WriteString(", ")
-			short.WriteString(", ")
-		}
-		long.WriteString(v.Name)
-		short.WriteString(v.Name)
-		long.WriteString(fmt.Sprintf(" %s", v.Type.String(g.packageMap, pkgOverride)))
-	}
-	long.WriteString("]")
-	short.WriteString("]")
-	return long.String(), short.String()
-}
-
-func (g *generator) GenerateMockInterface(intf *model.Interface, outputPackagePath string) error {
-	mockType := g.mockName(intf.Name)
-	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
-
-	g.p("")
-	g.p("// %v is a mock of %v interface.", mockType, intf.Name)
-	g.p("type %v%v struct {", mockType, longTp)
-	g.in()
-	g.p("ctrl     *gomock.Controller")
-	g.p("recorder *%vMockRecorder%v", mockType, shortTp)
-	g.p("isgomock struct{}")
-	g.out()
-	g.p("}")
-	g.p("")
-
-	g.p("// %vMockRecorder is the mock recorder for %v.", mockType, mockType)
-	g.p("type %vMockRecorder%v struct {", mockType, longTp)
-	g.in()
-	g.p("mock *%v%v", mockType, shortTp)
-	g.out()
-	g.p("}")
-	g.p("")
-
-	g.p("// New%v creates a new mock instance.", mockType)
-	g.p("func New%v%v(ctrl *gomock.Controller) *%v%v {", mockType, longTp, mockType, shortTp)
-	g.in()
-	g.p("mock := &%v%v{ctrl: ctrl}", mockType, shortTp)
-	g.p("mock.recorder = &%vMockRecorder%v{mock}", mockType, shortTp)
-	g.p("return mock")
-	g.out()
-	g.p("}")
-	g.p("")
-
-	// XXX: possible name collision here if someone has EXPECT in their interface.
-	g.p("// EXPECT returns an object that allows the caller to indicate expected use.")
-	g.p("func (m *%v%v) EXPECT() *%vMockRecorder%v {", mockType, shortTp, mockType, shortTp)
-	g.in()
-	g.p("return m.recorder")
-	g.out()
-	g.p("}")
-
-	g.GenerateMockMethods(mockType, intf, outputPackagePath, longTp, shortTp, *typed)
-
-	return nil
-}
-
-type byMethodName []*model.Method
-
-func (b byMethodName) Len() int           { return len(b) }
-func (b byMethodName) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
-func (b byMethodName) Less(i, j int) bool { return b[i].Name < b[j].Name }
-
-func (g *generator) GenerateMockMethods(mockType string, intf *model.Interface, pkgOverride, longTp, shortTp string, typed bool) {
-	sort.Sort(byMethodName(intf.Methods))
-	for _, m := range intf.Methods {
-		g.p("")
-		_ = g.GenerateMockMethod(mockType, m, pkgOverride, shortTp)
-		g.p("")
-		_ = g.GenerateMockRecorderMethod(intf, m, shortTp, typed)
-		if typed {
-			g.p("")
-			_ = g.GenerateMockReturnCallMethod(intf, m, pkgOverride, longTp, shortTp)
-		}
-	}
-}
-
-func makeArgString(argNames, argTypes []string) string {
-	args := make([]string, len(argNames))
-	for i, name := range argNames {
-		// specify the type only once for consecutive args of the same type
-		if i+1 < len(argTypes) && argTypes[i] == argTypes[i+1] {
-			args[i] = name
-		} else {
-			args[i] = name + " " + argTypes[i]
-		}
-	}
-	return strings.Join(args, ", ")
-}
-
-// GenerateMockMethod generates a mock method implementation.
-// If non-empty, pkgOverride is the package in which unqualified types reside.
-func (g *generator) GenerateMockMethod(mockType string, m *model.Method, pkgOverride, shortTp string) error {
-	argNames := g.getArgNames(m, true /* in */)
-	argTypes := g.getArgTypes(m, pkgOverride, true /* in */)
-	argString := makeArgString(argNames, argTypes)
-
-	rets := make([]string, len(m.Out))
-	for i, p := range m.Out {
-		rets[i] = p.Type.String(g.packageMap, pkgOverride)
-	}
-	retString := strings.Join(rets, ", ")
-	if len(rets) > 1 {
-		retString = "(" + retString + ")"
-	}
-	if retString != "" {
-		retString = " " + retString
-	}
-
-	ia := newIdentifierAllocator(argNames)
-	idRecv := ia.allocateIdentifier("m")
-
-	g.p("// %v mocks base method.", m.Name)
-	g.p("func (%v *%v%v) %v(%v)%v {", idRecv, mockType, shortTp, m.Name, argString, retString)
-	g.in()
-	g.p("%s.ctrl.T.Helper()", idRecv)
-
-	var callArgs string
-	if m.Variadic == nil {
-		if len(argNames) > 0 {
-			callArgs = ", " + strings.Join(argNames, ", ")
-		}
-	} else {
-		// Non-trivial. The generated code must build a []any,
-		// but the variadic argument may be any type.
-		idVarArgs := ia.allocateIdentifier("varargs")
-		idVArg := ia.allocateIdentifier("a")
-		g.p("%s := []any{%s}", idVarArgs, strings.Join(argNames[:len(argNames)-1], ", "))
-		g.p("for _, %s := range %s {", idVArg, argNames[len(argNames)-1])
-		g.in()
-		g.p("%s = append(%s, %s)", idVarArgs, idVarArgs, idVArg)
-		g.out()
-		g.p("}")
-		callArgs = ", " + idVarArgs + "..."
-	}
-	if len(m.Out) == 0 {
-		g.p(`%v.ctrl.Call(%v, %q%v)`, idRecv, idRecv, m.Name, callArgs)
-	} else {
-		idRet := ia.allocateIdentifier("ret")
-		g.p(`%v := %v.ctrl.Call(%v, %q%v)`, idRet, idRecv, idRecv, m.Name, callArgs)
-
-		// Go does not allow "naked" type assertions on nil values, so we use the two-value form here.
-		// The value of that is either (x.(T), true) or (Z, false), where Z is the zero value for T.
-		// Happily, this coincides with the semantics we want here.
-		retNames := make([]string, len(rets))
-		for i, t := range rets {
-			retNames[i] = ia.allocateIdentifier(fmt.Sprintf("ret%d", i))
-			g.p("%s, _ := %s[%d].(%s)", retNames[i], idRet, i, t)
-		}
-		g.p("return " + strings.Join(retNames, ", "))
-	}
-
-	g.out()
-	g.p("}")
-	return nil
-}
-
-func (g *generator) GenerateMockRecorderMethod(intf *model.Interface, m *model.Method, shortTp string, typed bool) error {
-	mockType := g.mockName(intf.Name)
-	argNames := g.getArgNames(m, true)
-
-	var argString string
-	if m.Variadic == nil {
-		argString = strings.Join(argNames, ", ")
-	} else {
-		argString = strings.Join(argNames[:len(argNames)-1], ", ")
-	}
-	if argString != "" {
-		argString += " any"
-	}
-
-	if m.Variadic != nil {
-		if argString != "" {
-			argString += ", "
-		}
-		argString += fmt.Sprintf("%s ...any", argNames[len(argNames)-1])
-	}
-
-	ia := newIdentifierAllocator(argNames)
-	idRecv := ia.allocateIdentifier("mr")
-
-	g.p("// %v indicates an expected call of %v.", m.Name, m.Name)
-	if typed {
-		g.p("func (%s *%vMockRecorder%v) %v(%v) *%s%sCall%s {", idRecv, mockType, shortTp, m.Name, argString, mockType, m.Name, shortTp)
-	} else {
-		g.p("func (%s *%vMockRecorder%v) %v(%v) *gomock.Call {", idRecv, mockType, shortTp, m.Name, argString)
-	}
-
-	g.in()
-	g.p("%s.mock.ctrl.T.Helper()", idRecv)
-
-	var callArgs string
-	if m.Variadic == nil {
-		if len(argNames) > 0 {
-			callArgs = ", " + strings.Join(argNames, ", ")
-		}
-	} else {
-		if len(argNames) == 1 {
-			// Easy: just use ... to push the arguments through.
-			callArgs = ", " + argNames[0] + "..."
-		} else {
-			// Hard: create a temporary slice.
-			idVarArgs := ia.allocateIdentifier("varargs")
-			g.p("%s := append([]any{%s}, %s...)",
-				idVarArgs,
-				strings.Join(argNames[:len(argNames)-1], ", "),
-				argNames[len(argNames)-1])
-			callArgs = ", " + idVarArgs + "..."
-		}
-	}
-	if typed {
-		g.p(`call := %s.mock.ctrl.RecordCallWithMethodType(%s.mock, "%s", reflect.TypeOf((*%s%s)(nil).%s)%s)`, idRecv, idRecv, m.Name, mockType, shortTp, m.Name, callArgs)
-		g.p(`return &%s%sCall%s{Call: call}`, mockType, m.Name, shortTp)
-	} else {
-		g.p(`return %s.mock.ctrl.RecordCallWithMethodType(%s.mock, "%s", reflect.TypeOf((*%s%s)(nil).%s)%s)`, idRecv, idRecv, m.Name, mockType, shortTp, m.Name, callArgs)
-	}
-
-	g.out()
-	g.p("}")
-	return nil
-}
-
-func (g *generator) GenerateMockReturnCallMethod(intf *model.Interface, m *model.Method, pkgOverride, longTp, shortTp string) error {
-	mockType := g.mockName(intf.Name)
-	argNames := g.getArgNames(m, true /* in */)
-	retNames := g.getArgNames(m, false /* out */)
-	argTypes := g.getArgTypes(m, pkgOverride, true /* in */)
-	retTypes := g.getArgTypes(m, pkgOverride, false /* out */)
-	argString := strings.Join(argTypes, ", ")
-
-	rets := make([]string, len(m.Out))
-	for i, p := range m.Out {
-		rets[i] = p.Type.String(g.packageMap, pkgOverride)
-	}
-
-	var retString string
-	switch {
-	case len(rets) == 1:
-		retString = " " + rets[0]
-	case len(rets) > 1:
-		retString = " (" + strings.Join(rets, ", ") + ")"
-	}
-
-	ia := newIdentifierAllocator(argNames)
-	idRecv := ia.allocateIdentifier("c")
-
-	recvStructName := mockType + m.Name
-
-	g.p("// %s%sCall wrap *gomock.Call", mockType, m.Name)
-	g.p("type %s%sCall%s struct{", mockType, m.Name, longTp)
-	g.in()
-	g.p("*gomock.Call")
-	g.out()
-	g.p("}")
-
-	g.p("// Return rewrite *gomock.Call.Return")
-	g.p("func (%s *%sCall%s) Return(%v) *%sCall%s {", idRecv, recvStructName, shortTp, makeArgString(retNames, retTypes), recvStructName, shortTp)
-	g.in()
-	var retArgs string
-	if len(retNames) > 0 {
-		retArgs = strings.Join(retNames, ", ")
-	}
-	g.p(`%s.Call =  %v.Call.Return(%v)`, idRecv, idRecv, retArgs)
-	g.p("return %s", idRecv)
-	g.out()
-	g.p("}")
-
-	g.p("// Do rewrite *gomock.Call.Do")
-	g.p("func (%s *%sCall%s) Do(f func(%v)%v) *%sCall%s {", idRecv, recvStructName, shortTp, argString, retString, recvStructName, shortTp)
-	g.in()
-	g.p(`%s.Call = %v.Call.Do(f)`, idRecv, idRecv)
-	g.p("return %s", idRecv)
-	g.out()
-	g.p("}")
-
-	g.p("// DoAndReturn rewrite *gomock.Call.DoAndReturn")
-	g.p("func (%s *%sCall%s) DoAndReturn(f func(%v)%v) *%sCall%s {", idRecv, recvStructName, shortTp, argString, retString, recvStructName, shortTp)
-	g.in()
-	g.p(`%s.Call = %v.Call.DoAndReturn(f)`, idRecv, idRecv)
-	g.p("return %s", idRecv)
-	g.out()
-	g.p("}")
-	return nil
-}
-
-// nameExistsAsPackage returns true if the name exists as a package name.
-// This is used to avoid name collisions when generating mock method arguments.
-func (g *generator) nameExistsAsPackage(name string) bool {
-	for _, symbolName := range g.packageMap {
-		if symbolName == name {
-			return true
-		}
-	}
-	return false
-}
-
-func (g *generator) getArgNames(m *model.Method, in bool) []string {
-	var params []*model.Parameter
-	if in {
-		params = m.In
-	} else {
-		params = m.Out
-	}
-	argNames := make([]string, len(params))
-
-	for i, p := range params {
-		name := p.Name
-
-		if name == "" || name == "_" || g.nameExistsAsPackage(name) {
-			name = fmt.Sprintf("arg%d", i)
-		}
-		argNames[i] = name
-	}
-	if m.Variadic != nil && in {
-		name := m.Variadic.Name
-
-		if name == "" || g.nameExistsAsPackage(name) {
-			name = fmt.Sprintf("arg%d", len(params))
-		}
-		argNames = append(argNames, name)
-	}
-	return argNames
-}
-
-func (g *generator) getArgTypes(m *model.Method, pkgOverride string, in bool) []string {
-	var params []*model.Parameter
-	if in {
-		params = m.In
-	} else {
-		params = m.Out
-	}
-	argTypes := make([]string, len(params))
-	for i, p := range params {
-		argTypes[i] = p.Type.String(g.packageMap, pkgOverride)
-	}
-	if m.Variadic != nil {
-		argTypes = append(argTypes, "..."+m.Variadic.Type.String(g.packageMap, pkgOverride))
-	}
-	return argTypes
-}
-
-type identifierAllocator map[string]struct{}
-
-func newIdentifierAllocator(taken []string) identifierAllocator {
-	a := make(identifierAllocator, len(taken))
-	for _, s := range taken {
-		a[s] = struct{}{}
-	}
-	return a
-}
-
-func (o identifierAllocator) allocateIdentifier(want string) string {
-	id := want
-	for i := 2; ; i++ {
-		if _, ok := o[id]; !ok {
-			o[id] = struct{}{}
-			return id
-		}
-		id = want + "_" + strconv.Itoa(i)
-	}
-}
-
-// Output returns the generator's output, formatted in the standard Go style.
-func (g *generator) Output() []byte {
-	src, err := toolsimports.Process(g.destination, g.buf.Bytes(), nil)
+	packageImport, err := generate.PackageImportPath(srcDir)
 	if err != nil {
-		log.Fatalf("Failed to format generated source code: %s\n%s", err, g.buf.String())
-	}
-	return src
-}
-
-// createPackageMap returns a map of import path to package name
-// for specified importPaths.
-func createPackageMap(importPaths []string) map[string]string {
-	var pkg struct {
-		Name       string
-		ImportPath string
-	}
-	pkgMap := make(map[string]string)
-	b := bytes.NewBuffer(nil)
-	args := []string{"list", "-json=ImportPath,Name"}
-	args = append(args, importPaths...)
-	cmd := exec.Command("go", args...)
-	cmd.Stdout = b
-	cmd.Run()
-	dec := json.NewDecoder(b)
-	for dec.More() {
-		err := dec.Decode(&pkg)
-		if err != nil {
-			log.Printf("failed to decode 'go list' output: %v", err)
-			continue
-		}
-		pkgMap[pkg.ImportPath] = pkg.Name
+		return "", err
 	}
-	return pkgMap
+	return packageImport, nil
 }
 
 func printVersion() {
@@ -914,44 +498,3 @@ func printVersion() {
 		printModuleVersion()
 	}
 }
-
-// parseImportPackage get package import path via source file
-// an alternative implementation is to use:
-// cfg := &packages.Config{Mode: packages.NeedName, Tests: true, Dir: srcDir}
-// pkgs, err := packages.Load(cfg, "file="+source)
-// However, it will call "go list" and slow down the performance
-func parsePackageImport(srcDir string) (string, error) {
-	moduleMode := os.Getenv("GO111MODULE")
-	// trying to find the module
-	if moduleMode != "off" {
-		currentDir := srcDir
-		for {
-			dat, err := os.ReadFile(filepath.Join(currentDir, "go.mod"))
-			if os.IsNotExist(err) {
-				if currentDir == filepath.Dir(currentDir) {
-					// at the root
-					break
-				}
-				currentDir = filepath.Dir(currentDir)
-				continue
-			} else if err != nil {
-				return "", err
-			}
-			modulePath := modfile.ModulePath(dat)
-			return filepath.ToSlash(filepath.Join(modulePath, strings.TrimPrefix(srcDir, currentDir))), nil
-		}
-	}
-	// fall back to GOPATH mode
-	goPaths := os.Getenv("GOPATH")
-	if goPaths == "" {
-		return "", fmt.Errorf("GOPATH is not set")
-	}
-	goPathList := strings.Split(goPaths, string(os.PathListSeparator))
-	for _, goPath := range goPathList {
-		sourceRoot := filepath.Join(goPath, "src") + string(os.PathSeparator)
-		if strings.HasPrefix(srcDir, sourceRoot) {
-			return filepath.ToSlash(strings.TrimPrefix(srcDir, sourceRoot)), nil
-		}
-	}
-	return "", errOutsideGoPath
-}
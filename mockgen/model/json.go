@@ -0,0 +1,483 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+)
+
+// The JSON encoding of a Package is a stable schema for tools written in other
+// languages. Its objects are:
+//
+//	Package:   {"name", "pkgPath", "interfaces": [Interface], "dotImports": [string]}
+//	Interface: {"name", "methods": [Method], "typeParams": [Parameter],
+//	            "funcType", "extracted", "instance": Type, "doc", "pos": Position}
+//	Method:    {"name", "in": [Parameter], "out": [Parameter],
+//	            "variadic": Parameter, "doc", "pos": Position}
+//	Parameter: {"name", "type": Type, "pos": Position}
+//	Position:  {"filename", "offset", "line", "column"}
+//
+// A Type is an object whose "kind" selects its other fields:
+//
+//	{"kind": "predeclared", "name"}                    e.g. int, error, any
+//	{"kind": "named", "package", "name", "typeArgs": [Type]}
+//	{"kind": "pointer", "elem": Type}
+//	{"kind": "slice", "elem": Type}
+//	{"kind": "array", "len", "elem": Type}
+//	{"kind": "map", "key": Type, "value": Type}
+//	{"kind": "chan", "dir": "recv" | "send" | absent, "elem": Type}
+//	{"kind": "func", "in": [Parameter], "out": [Parameter], "variadic": Parameter}
+//	{"kind": "union", "terms": [{"tilde", "type": Type}]}
+//
+// Empty fields are omitted. The type parameters of generic interfaces are
+// named types of no package in package mode and predeclared types in source
+// mode.
+
+// JSON kinds of types.
+const (
+	jsonKindPredeclared = "predeclared"
+	jsonKindNamed       = "named"
+	jsonKindPointer     = "pointer"
+	jsonKindSlice       = "slice"
+	jsonKindArray       = "array"
+	jsonKindMap         = "map"
+	jsonKindChan        = "chan"
+	jsonKindFunc        = "func"
+	jsonKindUnion       = "union"
+)
+
+type jsonPackage struct {
+	Name       string           `json:"name"`
+	PkgPath    string           `json:"pkgPath"`
+	Interfaces []*jsonInterface `json:"interfaces"`
+	DotImports []string         `json:"dotImports,omitempty"`
+}
+
+type jsonInterface struct {
+	Name       string           `json:"name"`
+	Methods    []*jsonMethod    `json:"methods"`
+	TypeParams []*jsonParameter `json:"typeParams,omitempty"`
+	FuncType   bool             `json:"funcType,omitempty"`
+	Extracted  bool             `json:"extracted,omitempty"`
+	Instance   *jsonType        `json:"instance,omitempty"`
+	Doc        string           `json:"doc,omitempty"`
+	Pos        *jsonPosition    `json:"pos,omitempty"`
+}
+
+type jsonMethod struct {
+	Name     string           `json:"name"`
+	In       []*jsonParameter `json:"in,omitempty"`
+	Out      []*jsonParameter `json:"out,omitempty"`
+	Variadic *jsonParameter   `json:"variadic,omitempty"`
+	Doc      string           `json:"doc,omitempty"`
+	Pos      *jsonPosition    `json:"pos,omitempty"`
+}
+
+type jsonParameter struct {
+	Name string        `json:"name,omitempty"`
+	Type *jsonType     `json:"type"`
+	Pos  *jsonPosition `json:"pos,omitempty"`
+}
+
+type jsonPosition struct {
+	Filename string `json:"filename"`
+	Offset   int    `json:"offset"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+}
+
+type jsonType struct {
+	Kind     string           `json:"kind"`
+	Package  string           `json:"package,omitempty"`
+	Name     string           `json:"name,omitempty"`
+	TypeArgs []*jsonType      `json:"typeArgs,omitempty"`
+	Len      int              `json:"len,omitempty"`
+	Dir      string           `json:"dir,omitempty"`
+	Elem     *jsonType        `json:"elem,omitempty"`
+	Key      *jsonType        `json:"key,omitempty"`
+	Value    *jsonType        `json:"value,omitempty"`
+	In       []*jsonParameter `json:"in,omitempty"`
+	Out      []*jsonParameter `json:"out,omitempty"`
+	Variadic *jsonParameter   `json:"variadic,omitempty"`
+	Terms    []*jsonUnionTerm `json:"terms,omitempty"`
+}
+
+type jsonUnionTerm struct {
+	Tilde bool      `json:"tilde,omitempty"`
+	Type  *jsonType `json:"type"`
+}
+
+// MarshalJSON encodes the package in the JSON schema of the model.
+func (pkg *Package) MarshalJSON() ([]byte, error) {
+	jp := &jsonPackage{
+		Name:       pkg.Name,
+		PkgPath:    pkg.PkgPath,
+		Interfaces: make([]*jsonInterface, len(pkg.Interfaces)),
+		DotImports: pkg.DotImports,
+	}
+	for i, intf := range pkg.Interfaces {
+		ji, err := interfaceToJSON(intf)
+		if err != nil {
+			return nil, fmt.Errorf("interface %s: %w", intf.Name, err)
+		}
+		jp.Interfaces[i] = ji
+	}
+	return json.Marshal(jp)
+}
+
+// UnmarshalJSON decodes a package in the JSON schema of the model.
+func (pkg *Package) UnmarshalJSON(data []byte) error {
+	var jp jsonPackage
+	if err := json.Unmarshal(data, &jp); err != nil {
+		return err
+	}
+	*pkg = Package{
+		Name:       jp.Name,
+		PkgPath:    jp.PkgPath,
+		DotImports: jp.DotImports,
+	}
+	for _, ji := range jp.Interfaces {
+		if ji == nil {
+			return errors.New("null interface")
+		}
+		intf, err := interfaceFromJSON(ji)
+		if err != nil {
+			return fmt.Errorf("interface %s: %w", ji.Name, err)
+		}
+		pkg.Interfaces = append(pkg.Interfaces, intf)
+	}
+	return nil
+}
+
+func interfaceToJSON(intf *Interface) (*jsonInterface, error) {
+	ji := &jsonInterface{
+		Name:      intf.Name,
+		Methods:   make([]*jsonMethod, len(intf.Methods)),
+		FuncType:  intf.FuncType,
+		Extracted: intf.Extracted,
+		Doc:       intf.Doc,
+		Pos:       positionToJSON(intf.Pos),
+	}
+	var err error
+	if ji.TypeParams, err = paramsToJSON(intf.TypeParams); err != nil {
+		return nil, fmt.Errorf("type parameters: %w", err)
+	}
+	if intf.Instance != nil {
+		if ji.Instance, err = typeToJSON(intf.Instance); err != nil {
+			return nil, fmt.Errorf("instance: %w", err)
+		}
+	}
+	for i, m := range intf.Methods {
+		jm := &jsonMethod{Name: m.Name, Doc: m.Doc, Pos: positionToJSON(m.Pos)}
+		if jm.In, err = paramsToJSON(m.In); err == nil {
+			if jm.Out, err = paramsToJSON(m.Out); err == nil {
+				jm.Variadic, err = paramToJSON(m.Variadic)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("method %s: %w", m.Name, err)
+		}
+		ji.Methods[i] = jm
+	}
+	return ji, nil
+}
+
+func interfaceFromJSON(ji *jsonInterface) (*Interface, error) {
+	intf := &Interface{
+		Name:      ji.Name,
+		FuncType:  ji.FuncType,
+		Extracted: ji.Extracted,
+		Doc:       ji.Doc,
+		Pos:       positionFromJSON(ji.Pos),
+	}
+	var err error
+	if intf.TypeParams, err = paramsFromJSON(ji.TypeParams); err != nil {
+		return nil, fmt.Errorf("type parameters: %w", err)
+	}
+	if ji.Instance != nil {
+		t, err := typeFromJSON(ji.Instance)
+		if err != nil {
+			return nil, fmt.Errorf("instance: %w", err)
+		}
+		nt, ok := t.(*NamedType)
+		if !ok {
+			return nil, fmt.Errorf("instance: got %s type, want named", ji.Instance.Kind)
+		}
+		intf.Instance = nt
+	}
+	for _, jm := range ji.Methods {
+		if jm == nil {
+			return nil, errors.New("null method")
+		}
+		m := &Method{Name: jm.Name, Doc: jm.Doc, Pos: positionFromJSON(jm.Pos)}
+		if m.In, err = paramsFromJSON(jm.In); err == nil {
+			if m.Out, err = paramsFromJSON(jm.Out); err == nil {
+				m.Variadic, err = paramFromJSON(jm.Variadic)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("method %s: %w", jm.Name, err)
+		}
+		intf.Methods = append(intf.Methods, m)
+	}
+	return intf, nil
+}
+
+func positionToJSON(pos token.Position) *jsonPosition {
+	if !pos.IsValid() {
+		return nil
+	}
+	return &jsonPosition{Filename: pos.Filename, Offset: pos.Offset, Line: pos.Line, Column: pos.Column}
+}
+
+func positionFromJSON(jp *jsonPosition) token.Position {
+	if jp == nil {
+		return token.Position{}
+	}
+	return token.Position{Filename: jp.Filename, Offset: jp.Offset, Line: jp.Line, Column: jp.Column}
+}
+
+func paramsToJSON(params []*Parameter) ([]*jsonParameter, error) {
+	if params == nil {
+		return nil, nil
+	}
+	jps := make([]*jsonParameter, len(params))
+	for i, p := range params {
+		jp, err := paramToJSON(p)
+		if err != nil {
+			return nil, err
+		}
+		jps[i] = jp
+	}
+	return jps, nil
+}
+
+func paramToJSON(p *Parameter) (*jsonParameter, error) {
+	if p == nil {
+		return nil, nil
+	}
+	jt, err := typeToJSON(p.Type)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonParameter{Name: p.Name, Type: jt, Pos: positionToJSON(p.Pos)}, nil
+}
+
+func paramsFromJSON(jps []*jsonParameter) ([]*Parameter, error) {
+	if jps == nil {
+		return nil, nil
+	}
+	params := make([]*Parameter, len(jps))
+	for i, jp := range jps {
+		if jp == nil {
+			return nil, errors.New("null parameter")
+		}
+		p, err := paramFromJSON(jp)
+		if err != nil {
+			return nil, err
+		}
+		params[i] = p
+	}
+	return params, nil
+}
+
+func paramFromJSON(jp *jsonParameter) (*Parameter, error) {
+	if jp == nil {
+		return nil, nil
+	}
+	t, err := typeFromJSON(jp.Type)
+	if err != nil {
+		return nil, fmt.Errorf("parameter %s: %w", jp.Name, err)
+	}
+	return &Parameter{Name: jp.Name, Type: t, Pos: positionFromJSON(jp.Pos)}, nil
+}
+
+func typesToJSON(ts []Type) ([]*jsonType, error) {
+	jts := make([]*jsonType, len(ts))
+	for i, t := range ts {
+		jt, err := typeToJSON(t)
+		if err != nil {
+			return nil, err
+		}
+		jts[i] = jt
+	}
+	return jts, nil
+}
+
+func typeToJSON(t Type) (*jsonType, error) {
+	var err error
+	switch t := t.(type) {
+	case PredeclaredType:
+		return &jsonType{Kind: jsonKindPredeclared, Name: string(t)}, nil
+	case *NamedType:
+		jt := &jsonType{Kind: jsonKindNamed, Package: t.Package, Name: t.Type}
+		if t.TypeParams != nil && len(t.TypeParams.TypeParameters) > 0 {
+			if jt.TypeArgs, err = typesToJSON(t.TypeParams.TypeParameters); err != nil {
+				return nil, err
+			}
+		}
+		return jt, nil
+	case *PointerType:
+		jt := &jsonType{Kind: jsonKindPointer}
+		jt.Elem, err = typeToJSON(t.Type)
+		return jt, err
+	case *ArrayType:
+		jt := &jsonType{Kind: jsonKindSlice}
+		if t.Len >= 0 {
+			jt.Kind, jt.Len = jsonKindArray, t.Len
+		}
+		jt.Elem, err = typeToJSON(t.Type)
+		return jt, err
+	case *MapType:
+		jt := &jsonType{Kind: jsonKindMap}
+		if jt.Key, err = typeToJSON(t.Key); err == nil {
+			jt.Value, err = typeToJSON(t.Value)
+		}
+		return jt, err
+	case *ChanType:
+		jt := &jsonType{Kind: jsonKindChan}
+		switch t.Dir {
+		case RecvDir:
+			jt.Dir = "recv"
+		case SendDir:
+			jt.Dir = "send"
+		}
+		jt.Elem, err = typeToJSON(t.Type)
+		return jt, err
+	case *FuncType:
+		jt := &jsonType{Kind: jsonKindFunc}
+		if jt.In, err = paramsToJSON(t.In); err == nil {
+			if jt.Out, err = paramsToJSON(t.Out); err == nil {
+				jt.Variadic, err = paramToJSON(t.Variadic)
+			}
+		}
+		return jt, err
+	case *UnionType:
+		jt := &jsonType{Kind: jsonKindUnion, Terms: make([]*jsonUnionTerm, len(t.Terms))}
+		for i, term := range t.Terms {
+			tt, err := typeToJSON(term.Type)
+			if err != nil {
+				return nil, err
+			}
+			jt.Terms[i] = &jsonUnionTerm{Tilde: term.Tilde, Type: tt}
+		}
+		return jt, nil
+	}
+	return nil, fmt.Errorf("cannot encode type %T", t)
+}
+
+func typesFromJSON(jts []*jsonType) ([]Type, error) {
+	ts := make([]Type, len(jts))
+	for i, jt := range jts {
+		t, err := typeFromJSON(jt)
+		if err != nil {
+			return nil, err
+		}
+		ts[i] = t
+	}
+	return ts, nil
+}
+
+func typeFromJSON(jt *jsonType) (Type, error) {
+	if jt == nil {
+		return nil, errors.New("missing type")
+	}
+	// elem decodes the element type of pointer, slice, array and chan types.
+	elem := func() (Type, error) {
+		if jt.Elem == nil {
+			return nil, fmt.Errorf("%s type without elem", jt.Kind)
+		}
+		return typeFromJSON(jt.Elem)
+	}
+	switch jt.Kind {
+	case jsonKindPredeclared:
+		if jt.Name == "" {
+			return nil, errors.New("predeclared type without name")
+		}
+		return PredeclaredType(jt.Name), nil
+	case jsonKindNamed:
+		if jt.Name == "" {
+			return nil, errors.New("named type without name")
+		}
+		nt := &NamedType{Package: jt.Package, Type: jt.Name}
+		if len(jt.TypeArgs) > 0 {
+			args, err := typesFromJSON(jt.TypeArgs)
+			if err != nil {
+				return nil, err
+			}
+			nt.TypeParams = &TypeParametersType{TypeParameters: args}
+		}
+		return nt, nil
+	case jsonKindPointer:
+		t, err := elem()
+		if err != nil {
+			return nil, err
+		}
+		return &PointerType{Type: t}, nil
+	case jsonKindSlice, jsonKindArray:
+		t, err := elem()
+		if err != nil {
+			return nil, err
+		}
+		n := -1
+		if jt.Kind == jsonKindArray {
+			n = jt.Len
+		}
+		return &ArrayType{Len: n, Type: t}, nil
+	case jsonKindMap:
+		key, err := typeFromJSON(jt.Key)
+		if err != nil {
+			return nil, fmt.Errorf("map key: %w", err)
+		}
+		value, err := typeFromJSON(jt.Value)
+		if err != nil {
+			return nil, fmt.Errorf("map value: %w", err)
+		}
+		return &MapType{Key: key, Value: value}, nil
+	case jsonKindChan:
+		var dir ChanDir
+		switch jt.Dir {
+		case "":
+		case "recv":
+			dir = RecvDir
+		case "send":
+			dir = SendDir
+		default:
+			return nil, fmt.Errorf("unknown chan dir %q", jt.Dir)
+		}
+		t, err := elem()
+		if err != nil {
+			return nil, err
+		}
+		return &ChanType{Dir: dir, Type: t}, nil
+	case jsonKindFunc:
+		in, err := paramsFromJSON(jt.In)
+		if err != nil {
+			return nil, err
+		}
+		out, err := paramsFromJSON(jt.Out)
+		if err != nil {
+			return nil, err
+		}
+		variadic, err := paramFromJSON(jt.Variadic)
+		if err != nil {
+			return nil, err
+		}
+		return &FuncType{In: in, Out: out, Variadic: variadic}, nil
+	case jsonKindUnion:
+		ut := &UnionType{}
+		for _, term := range jt.Terms {
+			if term == nil {
+				return nil, errors.New("null union term")
+			}
+			t, err := typeFromJSON(term.Type)
+			if err != nil {
+				return nil, err
+			}
+			ut.Terms = append(ut.Terms, &UnionTerm{Tilde: term.Tilde, Type: t})
+		}
+		return ut, nil
+	}
+	return nil, fmt.Errorf("unknown type kind %q", jt.Kind)
+}
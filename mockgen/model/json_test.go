@@ -0,0 +1,126 @@
+package model
+
+import (
+	"encoding/json"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPackageJSON(t *testing.T) {
+	pos := token.Position{Filename: "store.go", Offset: 10, Line: 2, Column: 6}
+	pkg := &Package{
+		Name:       "store",
+		PkgPath:    "example.com/store",
+		DotImports: []string{"example.com/dot"},
+		Interfaces: []*Interface{
+			{
+				Name: "Store",
+				TypeParams: []*Parameter{
+					{Name: "K", Type: PredeclaredType("comparable")},
+					{Name: "V", Type: &UnionType{Terms: []*UnionTerm{
+						{Tilde: true, Type: PredeclaredType("int")},
+						{Type: &NamedType{Package: "example.com/store", Type: "ID"}},
+					}}},
+				},
+				Doc: "Store stores.",
+				Pos: pos,
+				Methods: []*Method{
+					{
+						Name: "Get",
+						In: []*Parameter{
+							{Name: "ctx", Type: &NamedType{Package: "context", Type: "Context"}, Pos: pos},
+							{Name: "keys", Type: &ArrayType{Len: -1, Type: &PointerType{Type: PredeclaredType("string")}}},
+							{Type: &ArrayType{Len: 0, Type: PredeclaredType("byte")}},
+							{Type: &ArrayType{Len: 4, Type: PredeclaredType("byte")}},
+						},
+						Variadic: &Parameter{Name: "opts", Type: &FuncType{
+							In:       []*Parameter{{Type: &MapType{Key: PredeclaredType("string"), Value: PredeclaredType("any")}}},
+							Variadic: &Parameter{Type: PredeclaredType("int")},
+						}},
+						Out: []*Parameter{
+							{Type: &ChanType{Dir: RecvDir, Type: PredeclaredType("int")}},
+							{Type: &ChanType{Dir: SendDir, Type: PredeclaredType("int")}},
+							{Type: &ChanType{Type: &NamedType{
+								Package:    "example.com/store",
+								Type:       "Result",
+								TypeParams: &TypeParametersType{TypeParameters: []Type{&NamedType{Type: "V"}}},
+							}}},
+						},
+					},
+				},
+			},
+			{
+				Name:     "UserHandler",
+				FuncType: true,
+				Instance: &NamedType{
+					Package:    "example.com/store",
+					Type:       "Handler",
+					TypeParams: &TypeParametersType{TypeParameters: []Type{&NamedType{Package: "example.com/models", Type: "User"}}},
+				},
+				Methods: []*Method{{Name: FuncTypeMethod}},
+			},
+			{Name: "Empty", Extracted: true, Methods: []*Method{}},
+		},
+	}
+
+	data, err := json.Marshal(pkg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Package
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	// Empty slices are decoded as nil.
+	pkg.Interfaces[2].Methods = nil
+	if !reflect.DeepEqual(&got, pkg) {
+		t.Errorf("round trip of %s:\ngot  %+v\nwant %+v", data, &got, pkg)
+	}
+	for _, want := range []string{
+		`"name":"store"`,
+		`{"kind":"named","package":"context","name":"Context"}`,
+		`{"kind":"slice","elem":{"kind":"pointer","elem":{"kind":"predeclared","name":"string"}}}`,
+		`{"kind":"array","elem":{"kind":"predeclared","name":"byte"}}`,
+		`{"kind":"array","len":4,`,
+		`{"kind":"chan","dir":"recv",`,
+		`{"kind":"union","terms":[{"tilde":true,`,
+		`"typeArgs":[{"kind":"named","name":"V"}]`,
+		`"pos":{"filename":"store.go","offset":10,"line":2,"column":6}`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("%s does not contain %s", data, want)
+		}
+	}
+}
+
+func TestPackageJSON_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{"syntax", `{`, "unexpected end of JSON input"},
+		{"unknown kind", `{"interfaces":[{"name":"I","methods":[{"name":"M","in":[{"name":"x","type":{"kind":"struct"}}]}]}]}`, `interface I: method M: parameter x: unknown type kind "struct"`},
+		{"missing type", `{"interfaces":[{"name":"I","methods":[{"name":"M","out":[{}]}]}]}`, "interface I: method M: parameter : missing type"},
+		{"missing elem", `{"interfaces":[{"name":"I","methods":[{"name":"M","in":[{"type":{"kind":"pointer"}}]}]}]}`, "pointer type without elem"},
+		{"bad chan dir", `{"interfaces":[{"name":"I","methods":[{"name":"M","in":[{"type":{"kind":"chan","dir":"both"}}]}]}]}`, `unknown chan dir "both"`},
+		{"bad instance", `{"interfaces":[{"name":"I","instance":{"kind":"predeclared","name":"int"},"methods":[]}]}`, "instance: got predeclared type, want named"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var pkg Package
+			err := json.Unmarshal([]byte(tc.json), &pkg)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("Unmarshal() error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+
+	if _, err := json.Marshal(&Package{Interfaces: []*Interface{{
+		Name:    "I",
+		Methods: []*Method{{Name: "M", In: []*Parameter{{Type: &TypeParametersType{}}}}},
+	}}}); err == nil || !strings.Contains(err.Error(), "interface I: method M: cannot encode type *model.TypeParametersType") {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+}
@@ -18,6 +18,7 @@ package model
 import (
 	"encoding/gob"
 	"fmt"
+	"go/token"
 	"io"
 	"reflect"
 	"strings"
@@ -59,11 +60,52 @@ type Interface struct {
 	Name       string
 	Methods    []*Method
 	TypeParams []*Parameter
+	// FuncType is set if the interface was synthesized from a named function
+	// type. Its only method is named FuncTypeMethod and has the signature of
+	// the function type.
+	FuncType bool
+	// Extracted is set if the interface was extracted from the exported
+	// method set of a concrete type of the same name.
+	Extracted bool
+	// Instance is set if the interface is an instantiation of a generic
+	// interface or function type. It is the instantiated type, e.g.
+	// Repo[example.com/models.User], and Name is the name of the
+	// instantiation, e.g. UserRepo.
+	Instance *NamedType
+	Doc      string         // doc comment, may be empty
+	Pos      token.Position // position of the type name, invalid if unknown
+}
+
+// FuncTypeMethod is the name of the method of an interface synthesized from
+// a named function type.
+const FuncTypeMethod = "Call"
+
+// NewFuncTypeInterface returns the interface synthesized from the named
+// function type name with signature ft.
+func NewFuncTypeInterface(name string, ft *FuncType, typeParams []*Parameter) *Interface {
+	return &Interface{
+		Name: name,
+		Methods: []*Method{{
+			Name:     FuncTypeMethod,
+			In:       ft.In,
+			Out:      ft.Out,
+			Variadic: ft.Variadic,
+		}},
+		TypeParams: typeParams,
+		FuncType:   true,
+	}
 }
 
 // Print writes the interface name and its methods.
 func (intf *Interface) Print(w io.Writer) {
-	_, _ = fmt.Fprintf(w, "interface %s\n", intf.Name)
+	kind := "interface"
+	switch {
+	case intf.FuncType:
+		kind = "func type"
+	case intf.Extracted:
+		kind = "extracted interface"
+	}
+	_, _ = fmt.Fprintf(w, "%s %s\n", kind, intf.Name)
 	for _, m := range intf.Methods {
 		m.Print(w)
 	}
@@ -73,6 +115,10 @@ func (intf *Interface) addImports(im map[string]bool) {
 	for _, m := range intf.Methods {
 		m.addImports(im)
 	}
+	// The mock of an instantiated function type returns the instance.
+	if intf.FuncType && intf.Instance != nil {
+		intf.Instance.addImports(im)
+	}
 }
 
 // AddMethod adds a new method, de-duplicating by method name.
@@ -89,7 +135,9 @@ func (intf *Interface) AddMethod(m *Method) {
 type Method struct {
 	Name     string
 	In, Out  []*Parameter
-	Variadic *Parameter // may be nil
+	Variadic *Parameter     // may be nil
+	Doc      string         // doc comment, may be empty
+	Pos      token.Position // position of the method name, invalid if unknown
 }
 
 // Print writes the method name and its signature.
@@ -129,6 +177,7 @@ func (m *Method) addImports(im map[string]bool) {
 type Parameter struct {
 	Name string // may be empty
 	Type Type
+	Pos  token.Position // invalid if unknown
 }
 
 // Print writes a method parameter.
@@ -155,6 +204,7 @@ func init() {
 	gob.RegisterName(pkgPath+".MapType", &MapType{})
 	gob.RegisterName(pkgPath+".NamedType", &NamedType{})
 	gob.RegisterName(pkgPath+".PointerType", &PointerType{})
+	gob.RegisterName(pkgPath+".UnionType", &UnionType{})
 
 	// Call gob.RegisterName to make sure it has the consistent name registered
 	// for both gob decoder and encoder.
@@ -305,6 +355,36 @@ type PredeclaredType string
 func (pt PredeclaredType) String(map[string]string, string) string { return string(pt) }
 func (pt PredeclaredType) addImports(map[string]bool)              {}
 
+// UnionType is a union of type terms, such as ~int | ~float64. It may only
+// appear as the constraint of a type parameter.
+type UnionType struct {
+	Terms []*UnionTerm
+}
+
+// UnionTerm is a term of a UnionType: Type, or its underlying type set if
+// Tilde is set.
+type UnionTerm struct {
+	Tilde bool
+	Type  Type
+}
+
+func (ut *UnionType) String(pm map[string]string, pkgOverride string) string {
+	terms := make([]string, len(ut.Terms))
+	for i, term := range ut.Terms {
+		terms[i] = term.Type.String(pm, pkgOverride)
+		if term.Tilde {
+			terms[i] = "~" + terms[i]
+		}
+	}
+	return strings.Join(terms, " | ")
+}
+
+func (ut *UnionType) addImports(im map[string]bool) {
+	for _, term := range ut.Terms {
+		term.Type.addImports(im)
+	}
+}
+
 // TypeParametersType contains type parameters for a NamedType.
 type TypeParametersType struct {
 	TypeParameters []Type